@@ -0,0 +1,107 @@
+package goinp
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+//=======================================
+// Confirm
+//=======================================
+
+// DestructiveConfirmation describes how a destructive action has to be confirmed.
+type DestructiveConfirmation struct {
+	// Phrase the user has to type in to confirm the action, for example the name of the resource to delete.
+	// If empty, a simple yes/no question is asked, with "no" as the default.
+	Phrase string
+	// CaseInsensitive accepts the Phrase regardless of the letter case.
+	CaseInsensitive bool
+	// Affected lists what the action will affect, printed before the question.
+	Affected []string
+	// AssumeYes is the caller's --yes style override: the action is confirmed without asking.
+	// In non-interactive mode (the input is not a terminal) this is required to proceed.
+	AssumeYes bool
+}
+
+// ConfirmDestructive asks the user to confirm a destructive action.
+// Returns true only if the user typed in the expected phrase (or answered yes, if there is no phrase).
+// If the input is not interactive and confirmation.AssumeYes is not set, ErrConfirmationRequired is returned.
+func ConfirmDestructive(messageToPrint string, confirmation DestructiveConfirmation, opts ...Option) (bool, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("ConfirmDestructive", boolOptions, []string{"WithHelp", "WithHelpURL", "WithSummaryFormatter"}); err != nil {
+		return false, err
+	}
+
+	if confirmation.AssumeYes {
+		return true, nil
+	}
+	if !isInteractive(c.reader) {
+		return false, ErrConfirmationRequired
+	}
+
+	printAffected(c, messageToPrint, confirmation.Affected)
+
+	if confirmation.Phrase == "" {
+		boolConfig := *c
+		boolConfig.defaultValue = false
+		boolConfig.hasDefault = true
+		return askForBool(&boolConfig, c.message(MsgConfirmAreYouSure))
+	}
+
+	phraseConfig := *c
+	phraseConfig.hasDefault = false
+	phraseConfig.optional = true
+	return askForPhrase(&phraseConfig, confirmation)
+}
+
+func askForPhrase(c *config, confirmation DestructiveConfirmation) (confirmed bool, err error) {
+	question := c.message(MsgConfirmTypePhrase, confirmation.Phrase)
+
+	var answer string
+	defer func() { c.finishQuestion(question, answer, err) }()
+
+	answer, err = c.askLine(linePrompt{prompt: c.currentTheme().Prompt.render(question)})
+	if err != nil {
+		return false, err
+	}
+	answer = strings.TrimSpace(answer)
+
+	if confirmation.CaseInsensitive {
+		return strings.EqualFold(answer, confirmation.Phrase), nil
+	}
+	return answer == confirmation.Phrase, nil
+}
+
+// ConfirmDestructiveFromReader ...
+//
+// Deprecated: use ConfirmDestructive with WithReader.
+func ConfirmDestructiveFromReader(messageToPrint string, confirmation DestructiveConfirmation, inputReader io.Reader) (bool, error) {
+	return ConfirmDestructive(messageToPrint, confirmation, WithReader(inputReader))
+}
+
+func printAffected(c *config, messageToPrint string, affected []string) {
+	theme := c.currentTheme()
+
+	c.printf("%s\n", theme.Prompt.render(messageToPrint))
+	if len(affected) == 0 {
+		return
+	}
+
+	c.println(theme.Error.render(c.message(MsgConfirmAffected)))
+	for _, item := range affected {
+		c.println(theme.Error.render("- " + item))
+	}
+}
+
+// isInteractive reports whether the answer can be typed in by a user.
+// Files (like os.Stdin) are only interactive if they are terminals,
+// any other reader is considered to be provided deliberately by the caller.
+func isInteractive(inputReader io.Reader) bool {
+	file, ok := inputReader.(interface{ Fd() uintptr })
+	if !ok {
+		return true
+	}
+	return terminal.IsTerminal(int(file.Fd()))
+}
@@ -0,0 +1,82 @@
+package goinp
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmDestructiveFromReader(t *testing.T) {
+	confirmation := DestructiveConfirmation{
+		Phrase:   "my-app",
+		Affected: []string{"my-app", "3 builds"},
+	}
+
+	t.Log("Phrase typed in")
+	{
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", confirmation, strings.NewReader("my-app\n"))
+		require.NoError(t, err)
+		require.True(t, confirmed)
+	}
+
+	t.Log("Phrase mismatch")
+	{
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", confirmation, strings.NewReader("yes\n"))
+		require.NoError(t, err)
+		require.False(t, confirmed)
+	}
+
+	t.Log("Phrase with different case")
+	{
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", confirmation, strings.NewReader("MY-APP\n"))
+		require.NoError(t, err)
+		require.False(t, confirmed)
+
+		caseInsensitive := confirmation
+		caseInsensitive.CaseInsensitive = true
+		confirmed, err = ConfirmDestructiveFromReader("Delete app?", caseInsensitive, strings.NewReader("MY-APP\n"))
+		require.NoError(t, err)
+		require.True(t, confirmed)
+	}
+
+	t.Log("NO input")
+	{
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", confirmation, strings.NewReader(""))
+		require.True(t, errors.Is(err, ErrEOF))
+		require.False(t, confirmed)
+	}
+
+	t.Log("NO phrase - defaults to no")
+	{
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", DestructiveConfirmation{}, strings.NewReader("\n"))
+		require.NoError(t, err)
+		require.False(t, confirmed)
+
+		confirmed, err = ConfirmDestructiveFromReader("Delete app?", DestructiveConfirmation{}, strings.NewReader("y\n"))
+		require.NoError(t, err)
+		require.True(t, confirmed)
+	}
+
+	t.Log("Non-interactive input")
+	{
+		nonInteractive, writer, err := os.Pipe()
+		require.NoError(t, err)
+		_, err = writer.WriteString("my-app\n")
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		defer func() { require.NoError(t, nonInteractive.Close()) }()
+
+		confirmed, err := ConfirmDestructiveFromReader("Delete app?", confirmation, nonInteractive)
+		require.True(t, errors.Is(err, ErrConfirmationRequired))
+		require.False(t, confirmed)
+
+		assumeYes := confirmation
+		assumeYes.AssumeYes = true
+		confirmed, err = ConfirmDestructiveFromReader("Delete app?", assumeYes, nonInteractive)
+		require.NoError(t, err)
+		require.True(t, confirmed)
+	}
+}
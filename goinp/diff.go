@@ -0,0 +1,592 @@
+package goinp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+//=======================================
+// Diff confirmation
+//=======================================
+
+// diffContext is the number of unchanged lines shown around the changes.
+const diffContext = 3
+
+// maxDiffSteps limits the search for the middle of the edit script (the time is quadratic in the number of changes),
+// the lines are shown as replaced beyond it.
+const maxDiffSteps = 1000
+
+// ConfirmDiff shows the changes between the old and the new content, and asks the user to accept them (y),
+// reject them (n) or edit the new content (e) in the editor set in $VISUAL or $EDITOR (vi by default).
+// After editing, the changes are shown and confirmed again.
+// Returns the final content: the new (or edited) content if accepted, the old content if rejected.
+// The diff is unified by default, see SideBySide. Long diffs are shown in the pager in TTY mode, see Page.
+// If there are no changes, the content is returned without asking.
+func ConfirmDiff(messageToPrint, oldContent, newContent string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("ConfirmDiff", []string{"WithHelp", "WithHelpURL", "WithSummaryFormatter", "SideBySide", "WithFileName", "WithExternalPager"}); err != nil {
+		return "", err
+	}
+	return confirmDiff(c, messageToPrint, oldContent, newContent)
+}
+
+func confirmDiff(c *config, messageToPrint, oldContent, newContent string) (string, error) {
+	// the questions share the buffered input
+	c.input()
+
+	choices := []Choice{
+		{Key: 'y', Help: c.message(MsgDiffAccept)},
+		{Key: 'n', Help: c.message(MsgDiffReject)},
+		{Key: 'e', Help: c.message(MsgDiffEdit)},
+	}
+
+	for {
+		ops := diffLines(splitLines(oldContent), splitLines(newContent))
+		if !hasChanges(ops) {
+			return newContent, nil
+		}
+
+		var lines []string
+		if c.sideBySide {
+			lines = c.sideBySideDiff(ops)
+		} else {
+			lines = c.unifiedDiff(ops)
+		}
+		if err := page(c, strings.Join(lines, "\n"), true); err != nil {
+			return "", err
+		}
+
+		choiceConfig := *c
+		choiceConfig.validators = nil
+		key, err := askForChoice(&choiceConfig, messageToPrint, choices)
+		if err != nil {
+			return "", err
+		}
+
+		switch key {
+		case 'y':
+			return newContent, nil
+		case 'n':
+			return oldContent, nil
+		}
+		if newContent, err = c.editContent(newContent); err != nil {
+			return "", err
+		}
+	}
+}
+
+// editContent opens the content in the user's editor, and returns the edited content.
+func (c *config) editContent(content string) (string, error) {
+	pattern := "goinp-*"
+	if c.fileName != "" {
+		base := filepath.Base(c.fileName)
+		ext := filepath.Ext(base)
+		pattern = strings.TrimSuffix(base, ext) + "-*" + ext
+	}
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// run by the shell like git does, so that the editor can have arguments
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, file.Name())
+	closeTerminal := c.attachTerminal(cmd)
+	defer closeTerminal()
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run the editor (%s): %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(edited), nil
+}
+
+// attachTerminal runs the command in the terminal (/dev/tty), even if the answers are piped in
+// or the questions are written somewhere else, and returns a function closing the terminal.
+// Without /dev/tty the command gets the standard input and output.
+func (c *config) attachTerminal(cmd *exec.Cmd) func() {
+	input, isFile := c.reader.(*os.File)
+	if isFile && c.console != nil {
+		// the keys typed in before the editor started would answer the next question
+		_, _ = c.input().Discard(c.input().Buffered())
+	}
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+		return func() { _ = tty.Close() }
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if isFile && c.console != nil {
+		cmd.Stdin = input
+	}
+	return func() {}
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffOp is a line of the diff: kept (' '), removed ('-') or added ('+').
+// oldLine and newLine are the (0 based) indexes of the line in the old and new content, the one it would have for the other kind.
+type diffOp struct {
+	kind    byte
+	text    string
+	oldLine int
+	newLine int
+}
+
+// diffLines returns the operations turning the old lines into the new ones, based on their longest common subsequence.
+// It's found with Myers' linear space algorithm, so that long files don't need a table of every pair of lines.
+func diffLines(oldLines, newLines []string) []diffOp {
+	// the lines are compared by their ids
+	ids := map[string]int{}
+	lineIDs := func(lines []string) []int {
+		result := make([]int, len(lines))
+		for idx, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			result[idx] = id
+		}
+		return result
+	}
+	d := &differ{a: lineIDs(oldLines), b: lineIDs(newLines)}
+	d.compare(0, len(oldLines), 0, len(newLines))
+
+	// the removed lines come before the added ones within a change, like in diff -u
+	kinds := d.kinds
+	for start := 0; start < len(kinds); {
+		if kinds[start] == ' ' {
+			start++
+			continue
+		}
+		end, removed := start, 0
+		for ; end < len(kinds) && kinds[end] != ' '; end++ {
+			if kinds[end] == '-' {
+				removed++
+			}
+		}
+		for idx := start; idx < end; idx++ {
+			kinds[idx] = '+'
+			if idx < start+removed {
+				kinds[idx] = '-'
+			}
+		}
+		start = end
+	}
+
+	ops := make([]diffOp, len(kinds))
+	i, j := 0, 0
+	for idx, kind := range kinds {
+		ops[idx] = diffOp{kind: kind, oldLine: i, newLine: j}
+		switch kind {
+		case ' ':
+			ops[idx].text = oldLines[i]
+			i++
+			j++
+		case '-':
+			ops[idx].text = oldLines[i]
+			i++
+		case '+':
+			ops[idx].text = newLines[j]
+			j++
+		}
+	}
+	return ops
+}
+
+// differ collects the kinds of the diff operations (' ', '-' or '+') turning a into b.
+type differ struct {
+	a     []int
+	b     []int
+	kinds []byte
+}
+
+// compare adds the operations turning a[aStart:aEnd] into b[bStart:bEnd].
+// The common prefix and suffix are kept as they are, the lines in between are split at the middle of
+// the shortest edit script and compared recursively.
+func (d *differ) compare(aStart, aEnd, bStart, bEnd int) {
+	for aStart < aEnd && bStart < bEnd && d.a[aStart] == d.b[bStart] {
+		d.kinds = append(d.kinds, ' ')
+		aStart++
+		bStart++
+	}
+	suffix := 0
+	for aStart < aEnd-suffix && bStart < bEnd-suffix && d.a[aEnd-1-suffix] == d.b[bEnd-1-suffix] {
+		suffix++
+	}
+	aEnd -= suffix
+	bEnd -= suffix
+
+	switch {
+	case aStart == aEnd:
+		d.add('+', bEnd-bStart)
+	case bStart == bEnd:
+		d.add('-', aEnd-aStart)
+	default:
+		x, y, ok := d.middle(aStart, aEnd, bStart, bEnd)
+		if !ok {
+			d.add('-', aEnd-aStart)
+			d.add('+', bEnd-bStart)
+			break
+		}
+		d.compare(aStart, x, bStart, y)
+		d.compare(x, aEnd, y, bEnd)
+	}
+	d.add(' ', suffix)
+}
+
+func (d *differ) add(kind byte, count int) {
+	for idx := 0; idx < count; idx++ {
+		d.kinds = append(d.kinds, kind)
+	}
+}
+
+// middle returns the point in the middle of the shortest edit script turning a[aStart:aEnd] into b[bStart:bEnd],
+// searching for it from both ends at the same time ("An O(ND) Difference Algorithm and Its Variations", E. Myers).
+// Returns false if the edit script is longer than maxDiffSteps from both ends.
+func (d *differ) middle(aStart, aEnd, bStart, bEnd int) (int, int, bool) {
+	n, m := aEnd-aStart, bEnd-bStart
+	maxD := minInt((n+m+1)/2, maxDiffSteps)
+	offset := maxD + 1
+	// forward[offset+k] is the furthest x reached on the k = x - y diagonal from the start,
+	// backward[offset+k] the furthest one from the end (x and y counted from the end)
+	forward, backward := make([]int, 2*maxD+3), make([]int, 2*maxD+3)
+	for idx := range forward {
+		forward[idx], backward[idx] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	// if the difference of the lengths is odd the paths meet while searching forward, otherwise backward
+	odd := delta%2 != 0
+	// the diagonals running off the edit graph are skipped
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step + forwardStart; k <= step-forwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aStart+x] == d.b[bStart+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			switch {
+			case x > n:
+				forwardEnd += 2
+			case y > m:
+				forwardStart += 2
+			case odd:
+				if other := offset + delta - k; other >= 0 && other < len(backward) && backward[other] != -1 && x >= n-backward[other] {
+					return aStart + x, bStart + y, true
+				}
+			}
+		}
+
+		for k := -step + backwardStart; k <= step-backwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aEnd-1-x] == d.b[bEnd-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			switch {
+			case x > n:
+				backwardEnd += 2
+			case y > m:
+				backwardStart += 2
+			case !odd:
+				if other := offset + delta - k; other >= 0 && other < len(forward) && forward[other] != -1 {
+					forwardX := forward[other]
+					if forwardX >= n-x {
+						return aStart + forwardX, bStart + forwardX - (other - offset), true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func hasChanges(ops []diffOp) bool {
+	for _, op := range ops {
+		if op.kind != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// diffHunks returns the [start, end) ranges of the operations to show: the changes with diffContext lines around them,
+// merged if they overlap.
+func diffHunks(ops []diffOp) [][2]int {
+	var hunks [][2]int
+	for idx, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start, end := maxInt(idx-diffContext, 0), minInt(idx+1+diffContext, len(ops))
+		if last := len(hunks) - 1; last >= 0 && start <= hunks[last][1] {
+			hunks[last][1] = end
+			continue
+		}
+		hunks = append(hunks, [2]int{start, end})
+	}
+	return hunks
+}
+
+// hunkHeader returns the "@@ -1,3 +1,4 @@" line of the hunk.
+func hunkHeader(ops []diffOp) string {
+	oldStart, newStart := ops[0].oldLine+1, ops[0].newLine+1
+	oldCount, newCount := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+}
+
+// changedParts returns the changed middle part of the removed and the added line, after their common prefix and suffix.
+// All are empty if less than half of the lines is common, as then the whole lines are changed.
+func changedParts(removed, added string) (prefix, removedPart, addedPart, suffix string) {
+	a, b := []rune(removed), []rune(added)
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		start++
+	}
+	end := 0
+	for end < len(a)-start && end < len(b)-start && a[len(a)-1-end] == b[len(b)-1-end] {
+		end++
+	}
+	if 2*(start+end) < maxInt(len(a), len(b)) {
+		return "", "", "", ""
+	}
+	return string(a[:start]), string(a[start : len(a)-end]), string(b[start : len(b)-end]), string(a[len(a)-end:])
+}
+
+// diffRow is a rendered line of the diff, its parts are rendered with the line's style, except the changed part.
+type diffRow struct {
+	op      diffOp
+	prefix  string
+	changed string
+	suffix  string
+}
+
+// diffRows pairs the removed lines with the added lines following them, to highlight the changed part of the lines.
+func diffRows(ops []diffOp) []diffRow {
+	rows := make([]diffRow, len(ops))
+	for idx, op := range ops {
+		rows[idx] = diffRow{op: op, prefix: op.text}
+	}
+
+	for idx := 0; idx < len(ops); {
+		if ops[idx].kind != '-' {
+			idx++
+			continue
+		}
+		removedStart := idx
+		for idx < len(ops) && ops[idx].kind == '-' {
+			idx++
+		}
+		addedStart := idx
+		for idx < len(ops) && ops[idx].kind == '+' {
+			idx++
+		}
+
+		for pair := 0; pair < addedStart-removedStart && addedStart+pair < idx; pair++ {
+			removed, added := &rows[removedStart+pair], &rows[addedStart+pair]
+			prefix, removedPart, addedPart, suffix := changedParts(removed.op.text, added.op.text)
+			if prefix == "" && suffix == "" {
+				continue
+			}
+			removed.prefix, removed.changed, removed.suffix = prefix, removedPart, suffix
+			added.prefix, added.changed, added.suffix = prefix, addedPart, suffix
+		}
+	}
+	return rows
+}
+
+// render renders the row in the given width (truncating it), without limit if width is 0.
+func (r diffRow) render(theme Theme, width int) string {
+	lineStyle, highlightStyle := Style(nil), Style(nil)
+	switch r.op.kind {
+	case '-':
+		lineStyle, highlightStyle = theme.Removed, theme.RemovedHighlight
+	case '+':
+		lineStyle, highlightStyle = theme.Added, theme.AddedHighlight
+	}
+
+	parts := []string{r.prefix, r.changed, r.suffix}
+	if width > 0 {
+		parts = truncateParts(parts, width)
+	}
+	var b strings.Builder
+	for idx, part := range parts {
+		if part == "" {
+			continue
+		}
+		if idx == 1 {
+			b.WriteString(highlightStyle.render(part))
+		} else {
+			b.WriteString(lineStyle.render(part))
+		}
+	}
+	return b.String()
+}
+
+// truncateParts truncates the text made up of the parts to the given width, like truncate: the ellipsis is counted in the width.
+func truncateParts(parts []string, width int) []string {
+	if displayWidth(strings.Join(parts, "")) <= width {
+		return parts
+	}
+
+	truncated := make([]string, len(parts))
+	remaining := width - 1
+	for idx, part := range parts {
+		if remaining <= 0 {
+			break
+		}
+		if displayWidth(part) <= remaining {
+			truncated[idx] = part
+			remaining -= displayWidth(part)
+			continue
+		}
+		truncated[idx] = prefixInWidth(part, remaining)
+		remaining = 0
+	}
+	for idx := len(truncated) - 1; idx >= 0; idx-- {
+		if truncated[idx] != "" || idx == 0 {
+			truncated[idx] += "…"
+			break
+		}
+	}
+	return truncated
+}
+
+// unifiedDiff renders the changes like "diff -u", with the hunk headers and the "+" / "-" / " " line prefixes.
+func (c *config) unifiedDiff(ops []diffOp) []string {
+	theme := c.currentTheme()
+	rows := diffRows(ops)
+
+	var lines []string
+	for _, hunk := range diffHunks(ops) {
+		lines = append(lines, theme.Help.render(hunkHeader(ops[hunk[0]:hunk[1]])))
+		for _, row := range rows[hunk[0]:hunk[1]] {
+			row.prefix = string(row.op.kind) + row.prefix
+			lines = append(lines, row.render(theme, 0))
+		}
+	}
+	return lines
+}
+
+// sideBySideDiff renders the old lines on the left and the new ones on the right, the changed lines next to each other.
+// The columns are separated like by sdiff: "|" for the changed lines, "<" for the removed and ">" for the added ones.
+func (c *config) sideBySideDiff(ops []diffOp) []string {
+	theme := c.currentTheme()
+	rows := diffRows(ops)
+
+	width := 160
+	if c.console != nil {
+		width, _ = c.console.size()
+	}
+	// a column for the cursor
+	columnWidth := maxInt((width-1-3)/2, 1)
+
+	cell := func(row *diffRow) string {
+		if row == nil {
+			return strings.Repeat(" ", columnWidth)
+		}
+		text := row.render(theme, columnWidth)
+		return text + strings.Repeat(" ", maxInt(columnWidth-displayWidth(text), 0))
+	}
+
+	var lines []string
+	for _, hunk := range diffHunks(ops) {
+		lines = append(lines, theme.Help.render(hunkHeader(ops[hunk[0]:hunk[1]])))
+
+		hunkRows := rows[hunk[0]:hunk[1]]
+		for idx := 0; idx < len(hunkRows); {
+			if hunkRows[idx].op.kind == ' ' {
+				lines = append(lines, strings.TrimRight(cell(&hunkRows[idx])+"   "+cell(&hunkRows[idx]), " "))
+				idx++
+				continue
+			}
+
+			var removed, added []*diffRow
+			for ; idx < len(hunkRows) && hunkRows[idx].op.kind == '-'; idx++ {
+				removed = append(removed, &hunkRows[idx])
+			}
+			for ; idx < len(hunkRows) && hunkRows[idx].op.kind == '+'; idx++ {
+				added = append(added, &hunkRows[idx])
+			}
+			for pair := 0; pair < maxInt(len(removed), len(added)); pair++ {
+				var left, right *diffRow
+				separator := " | "
+				if pair < len(removed) {
+					left = removed[pair]
+				} else {
+					separator = " > "
+				}
+				if pair < len(added) {
+					right = added[pair]
+				} else {
+					separator = " < "
+				}
+				lines = append(lines, strings.TrimRight(cell(left)+separator+cell(right), " "))
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,163 @@
+package goinp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOldConfig = "format_version: 11\nworkflows:\n  primary:\n    steps:\n    - git-clone@6: {}\n    - xcode-test@4:\n        inputs:\n        - scheme: App\n    - deploy-to-bitrise-io@2: {}\n"
+	testNewConfig = "format_version: 11\nworkflows:\n  primary:\n    steps:\n    - git-clone@8: {}\n    - xcode-test@4:\n        inputs:\n        - scheme: App\n    - deploy-to-bitrise-io@2: {}\n    - cache-push@2: {}\n"
+)
+
+func TestConfirmDiff(t *testing.T) {
+	t.Log("Unified diff - accept")
+	{
+		var out bytes.Buffer
+		res, err := ConfirmDiff("Save bitrise.yml?", testOldConfig, testNewConfig, WithReader(strings.NewReader("y\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, testNewConfig, res)
+		require.Equal(t, `@@ -2,8 +2,9 @@
+ workflows:
+   primary:
+     steps:
+-    - git-clone@6: {}
++    - git-clone@8: {}
+     - xcode-test@4:
+         inputs:
+         - scheme: App
+     - deploy-to-bitrise-io@2: {}
++    - cache-push@2: {}
+Save bitrise.yml? [y,n,e,?] : 
+`, out.String())
+	}
+
+	t.Log("Reject")
+	{
+		res, err := ConfirmDiff("Save bitrise.yml?", testOldConfig, testNewConfig, WithReader(strings.NewReader("n\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, testOldConfig, res)
+	}
+
+	t.Log("No changes")
+	{
+		var out bytes.Buffer
+		res, err := ConfirmDiff("Save bitrise.yml?", testOldConfig, testOldConfig, WithReader(strings.NewReader("")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, testOldConfig, res)
+		require.Equal(t, "", out.String())
+	}
+
+	t.Log("Edit, then accept")
+	{
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", `sh -c 'printf "format_version: 13\n" > "$0"'`)
+
+		var out bytes.Buffer
+		res, err := ConfirmDiff("Save bitrise.yml?", "format_version: 11\n", "format_version: 12\n", WithReader(strings.NewReader("e\ny\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, "format_version: 13\n", res)
+		require.Contains(t, out.String(), "-format_version: 11\n+format_version: 13\n")
+
+		// the editor draws in the terminal, not in the question's writer
+		t.Setenv("EDITOR", `sh -c 'printf "\033[0m"; printf "\033[0m" >&2'`)
+		out.Reset()
+		_, err = ConfirmDiff("Save bitrise.yml?", "format_version: 11\n", "format_version: 12\n", WithReader(strings.NewReader("e\ny\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.NotContains(t, out.String(), "\x1b[0m")
+
+		// the temporary file keeps the file's name and extension
+		t.Setenv("EDITOR", `sh -c 'case "$0" in */bitrise-*.yml) printf "format_version: 13\n" > "$0";; esac'`)
+		res, err = ConfirmDiff("Save bitrise.yml?", "format_version: 11\n", "format_version: 12\n", WithReader(strings.NewReader("e\ny\n")), WithWriter(&bytes.Buffer{}), WithFileName("./config/bitrise.yml"))
+		require.NoError(t, err)
+		require.Equal(t, "format_version: 13\n", res)
+	}
+
+	t.Log("Side by side")
+	{
+		var out bytes.Buffer
+		_, err := ConfirmDiff("Save bitrise.yml?", testOldConfig, testNewConfig, WithReader(strings.NewReader("y")), WithWriter(&out), SideBySide(), withConsole(fakeConsole{60, 40}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), `@@ -2,8 +2,9 @@
+workflows:                     workflows:
+  primary:                       primary:
+    steps:                         steps:
+    - git-clone@6: {}        |     - git-clone@8: {}
+    - xcode-test@4:                - xcode-test@4:
+        inputs:                        inputs:
+        - scheme: App                  - scheme: App
+    - deploy-to-bitrise-io@…       - deploy-to-bitrise-io@…
+                             >     - cache-push@2: {}
+`)
+	}
+
+	t.Log("Side by side - lines longer than a column")
+	{
+		var out bytes.Buffer
+		_, err := ConfirmDiff("Save?", strings.Repeat("a", 76)+"XYz\n", strings.Repeat("a", 76)+"QQz\n", WithReader(strings.NewReader("y\n")), WithWriter(&out), SideBySide(), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), strings.Repeat("a", 76)+"X… | "+strings.Repeat("a", 76)+"Q…\n")
+
+		// the ellipsis is counted in the width
+		require.Equal(t, []string{"ab", "c…", ""}, truncateParts([]string{"ab", "cd", "e"}, 4))
+		require.Equal(t, []string{"ab…", "", ""}, truncateParts([]string{"ab", "", "efg"}, 3))
+	}
+}
+
+func TestDiffHighlight(t *testing.T) {
+	theme := Theme{
+		Added:            func(text string) string { return "{+" + text + "}" },
+		Removed:          func(text string) string { return "{-" + text + "}" },
+		AddedHighlight:   func(text string) string { return "[+" + text + "]" },
+		RemovedHighlight: func(text string) string { return "[-" + text + "]" },
+	}
+	c := newConfig([]Option{WithTheme(theme), WithWriter(&bytes.Buffer{})})
+
+	lines := c.unifiedDiff(diffLines([]string{"a", "- git-clone@6: {}", "removed"}, []string{"a", "- git-clone@8: {}", "added"}))
+	require.Equal(t, []string{
+		"@@ -1,3 +1,3 @@",
+		" a",
+		"{--- git-clone@}[-6]{-: {}}",
+		"{--removed}",
+		"{++- git-clone@}[+8]{+: {}}",
+		"{++added}",
+	}, lines)
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Log("Minimal edit script")
+	{
+		ops := diffLines([]string{"a", "b", "c", "a", "b", "b", "a"}, []string{"c", "b", "a", "b", "a", "c"})
+		var kinds []byte
+		for _, op := range ops {
+			kinds = append(kinds, op.kind)
+		}
+		require.Equal(t, "-+ -  - +", string(kinds))
+		require.Equal(t, diffOp{kind: '+', text: "c", oldLine: 1, newLine: 0}, ops[1])
+	}
+
+	t.Log("Long contents")
+	{
+		var oldLines, newLines, rewritten []string
+		for idx := 0; idx < 20000; idx++ {
+			oldLines = append(oldLines, fmt.Sprintf("line %d", idx))
+			newLines = append(newLines, fmt.Sprintf("line %d", idx))
+			rewritten = append(rewritten, fmt.Sprintf("new line %d", idx))
+		}
+		newLines[100] = "changed"
+		newLines[15000] = "changed"
+
+		ops := diffLines(oldLines, newLines)
+		require.Equal(t, 20002, len(ops))
+		require.Equal(t, [][2]int{{97, 105}, {14998, 15006}}, diffHunks(ops))
+
+		ops = diffLines(oldLines, rewritten)
+		require.Equal(t, 40000, len(ops))
+		require.Equal(t, byte('-'), ops[19999].kind)
+		require.Equal(t, byte('+'), ops[20000].kind)
+	}
+}
@@ -0,0 +1,405 @@
+package goinp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//=======================================
+// Screen
+//=======================================
+
+// screen redraws a block of lines in place, in raw mode.
+type screen struct {
+	c *config
+	// lines drawn last.
+	lines []string
+	// cursorRow is the (physical) row of the cursor inside the block drawn last.
+	cursorRow int
+}
+
+// render replaces the previously drawn block with the given lines and moves the cursor to the given line and column.
+func (s *screen) render(lines []string, cursorLine, cursorCol int) {
+	width, _ := s.c.console.size()
+
+	var b strings.Builder
+	if s.cursorRow > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", s.cursorRow)
+	}
+	b.WriteString("\r\x1b[J")
+	b.WriteString(strings.Join(lines, "\r\n"))
+
+	// Lines wider than the terminal wrap, so the rows are counted instead of the lines.
+	rows, cursorRow := 0, 0
+	for idx, line := range lines {
+		if idx == cursorLine {
+			cursorRow = rows + cursorCol/width
+		}
+		rows += wrappedRows(line, width)
+	}
+	if up := rows - 1 - cursorRow; up > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", up)
+	}
+	b.WriteString("\r")
+	if col := cursorCol % width; col > 0 {
+		fmt.Fprintf(&b, "\x1b[%dC", col)
+	}
+
+	s.lines = lines
+	s.cursorRow = cursorRow
+	s.c.printf("%s", b.String())
+}
+
+// close leaves the block drawn last as it is and moves the cursor below it.
+func (s *screen) close() {
+	if len(s.lines) == 0 {
+		return
+	}
+	s.closeWith(s.lines)
+}
+
+// closeWith replaces the block drawn last with the given lines and moves the cursor below them.
+func (s *screen) closeWith(lines []string) {
+	last := len(lines) - 1
+	s.render(lines, last, displayWidth(lines[last]))
+	s.c.printf("\r\n")
+	s.lines = nil
+	s.cursorRow = 0
+}
+
+// clear erases the block drawn last, the cursor is left where the block started.
+func (s *screen) clear() {
+	s.render([]string{""}, 0, 0)
+	s.lines = nil
+	s.cursorRow = 0
+}
+
+func wrappedRows(line string, width int) int {
+	lineWidth := displayWidth(line)
+	if lineWidth == 0 {
+		return 1
+	}
+	return (lineWidth-1)/width + 1
+}
+
+// displayWidth returns the number of columns the text takes up in the terminal, ignoring the ANSI escape sequences.
+func displayWidth(text string) int {
+	width := 0
+	for _, r := range stripANSI(text) {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the number of columns the character takes up: 0 for the combining marks
+// and 2 for the wide (East Asian and emoji) characters.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1100 && r <= 0x115f,
+		r >= 0x2e80 && r <= 0xa4cf && r != 0x303f,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f,
+		r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
+
+// truncate shortens the text to the given width, ending it with "…" if it was cut.
+func truncate(text string, width int) string {
+	if displayWidth(text) <= width {
+		return text
+	}
+	return prefixInWidth(text, width-1) + "…"
+}
+
+// prefixInWidth returns the beginning of the text which fits the width, without ellipsis.
+func prefixInWidth(text string, width int) string {
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		if used+runeWidth(r) > width {
+			break
+		}
+		b.WriteRune(r)
+		used += runeWidth(r)
+	}
+	return b.String()
+}
+
+func stripANSI(text string) string {
+	if !strings.Contains(text, "\x1b[") {
+		return text
+	}
+
+	var b strings.Builder
+	inSequence := false
+	for idx := 0; idx < len(text); idx++ {
+		switch {
+		case inSequence:
+			if text[idx] >= 0x40 && text[idx] <= 0x7e {
+				inSequence = false
+			}
+		case text[idx] == '\x1b' && idx+1 < len(text) && text[idx+1] == '[':
+			inSequence = true
+			idx++
+		default:
+			b.WriteByte(text[idx])
+		}
+	}
+	return b.String()
+}
+
+//=======================================
+// Line editor
+//=======================================
+
+// lineEditor edits a line of answer in raw mode.
+type lineEditor struct {
+	c      *config
+	screen *screen
+	p      linePrompt
+	prompt string
+
+	buffer   []rune
+	cursor   int
+	showHelp bool
+	done     bool
+	// err is the validation error of the current buffer, shown under the input.
+	err error
+
+	// suggestions for the text in query, selected is the index of the one accepted by Tab.
+	suggestions []string
+	selected    int
+	query       string
+	suggested   bool
+}
+
+// editLine reads a line of answer in TTY mode, the header lines are drawn above the prompt.
+// The answer is validated while typing, Enter only accepts a valid answer.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) editLine(p linePrompt, prompt string) (string, error) {
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	if c.screen == nil {
+		c.screen = &screen{c: c}
+	}
+	e := &lineEditor{c: c, screen: c.screen, p: p, prompt: prompt}
+	return e.run()
+}
+
+func (e *lineEditor) run() (string, error) {
+	input := e.c.input()
+	e.updateSuggestions()
+	for {
+		e.render()
+
+		k, err := readKey(input)
+		if err != nil {
+			e.finish()
+			return "", err
+		}
+
+		switch k.code {
+		case keyInterrupt:
+			e.finish()
+			return "", ErrInterrupted
+		case keyEOF:
+			if len(e.buffer) == 0 {
+				e.finish()
+				return "", ErrEOF
+			}
+		case keyEnter:
+			if e.err = e.c.checkAnswer(e.p, e.answer()); e.err != nil {
+				continue
+			}
+			e.finish()
+			return string(e.buffer), nil
+		case keyBackspace:
+			if e.cursor > 0 {
+				e.buffer = append(e.buffer[:e.cursor-1], e.buffer[e.cursor:]...)
+				e.cursor--
+			}
+		case keyDelete:
+			if e.cursor < len(e.buffer) {
+				e.buffer = append(e.buffer[:e.cursor], e.buffer[e.cursor+1:]...)
+			}
+		case keyLeft:
+			if e.cursor > 0 {
+				e.cursor--
+			}
+		case keyTab:
+			e.acceptSuggestion()
+		case keyRight:
+			if e.cursor < len(e.buffer) {
+				e.cursor++
+			} else {
+				e.acceptSuggestion()
+			}
+		case keyUp:
+			e.selectSuggestion(-1)
+			continue
+		case keyDown:
+			e.selectSuggestion(1)
+			continue
+		case keyHome:
+			e.cursor = 0
+		case keyEnd:
+			e.cursor = len(e.buffer)
+		case keyRune:
+			if k.r == '?' && len(e.buffer) == 0 && e.c.hasHelp() {
+				e.showHelp = !e.showHelp
+				continue
+			}
+			if filter := e.filter(); filter != nil && !filter(k.r) {
+				continue
+			}
+			e.insert(k.r)
+		}
+
+		e.err = nil
+		if answer := e.answer(); answer != "" {
+			e.err = e.c.checkAnswer(e.p, answer)
+		}
+		e.updateSuggestions()
+	}
+}
+
+// updateSuggestions asks the completer again if the text changed.
+func (e *lineEditor) updateSuggestions() {
+	if e.c.completer == nil || (e.suggested && e.query == string(e.buffer)) {
+		return
+	}
+	e.query = string(e.buffer)
+	e.suggested = true
+	e.suggestions = e.c.suggestions(e.query)
+	e.selected = 0
+}
+
+func (e *lineEditor) selectSuggestion(delta int) {
+	count := len(e.suggestions)
+	if count > maxSuggestions {
+		count = maxSuggestions
+	}
+	if count == 0 {
+		return
+	}
+	e.selected = (e.selected + delta + count) % count
+}
+
+func (e *lineEditor) acceptSuggestion() {
+	if len(e.suggestions) == 0 {
+		return
+	}
+	e.buffer = []rune(e.suggestions[e.selected])
+	e.cursor = len(e.buffer)
+}
+
+// ghost returns the rest of the selected suggestion, shown greyed-out after the cursor
+// if the suggestion starts with the text and the cursor is at its end.
+func (e *lineEditor) ghost() string {
+	if len(e.suggestions) == 0 || e.cursor != len(e.buffer) || e.c.secret {
+		return ""
+	}
+	text := string(e.buffer)
+	if suggestion := e.suggestions[e.selected]; strings.HasPrefix(suggestion, text) {
+		return suggestion[len(text):]
+	}
+	return ""
+}
+
+func (e *lineEditor) suggestionLines() []string {
+	theme := e.c.currentTheme()
+
+	var lines []string
+	for idx, suggestion := range e.suggestions {
+		if idx == maxSuggestions {
+			break
+		}
+		if idx == e.selected {
+			lines = append(lines, theme.Selected.render("> "+suggestion))
+		} else {
+			lines = append(lines, theme.Help.render("  "+suggestion))
+		}
+	}
+	return lines
+}
+
+func (e *lineEditor) filter() CharFilter {
+	if e.c.charFilter != nil {
+		return e.c.charFilter
+	}
+	return e.p.filter
+}
+
+// answer returns the buffer as it would be returned, with the trailing spaces trimmed.
+func (e *lineEditor) answer() string {
+	return strings.TrimRight(string(e.buffer), " ")
+}
+
+func (e *lineEditor) insert(r rune) {
+	e.buffer = append(e.buffer, 0)
+	copy(e.buffer[e.cursor+1:], e.buffer[e.cursor:])
+	e.buffer[e.cursor] = r
+	e.cursor++
+}
+
+func (e *lineEditor) lines() []string {
+	theme := e.c.currentTheme()
+
+	input := e.text()
+	if !e.done {
+		if input == "" && e.c.placeholder != "" {
+			input = theme.Placeholder.render(e.c.placeholder)
+		} else if ghost := e.ghost(); ghost != "" {
+			input += theme.Placeholder.render(ghost)
+		}
+	}
+
+	lines := append(append([]string{}, e.p.header...), e.prompt+input)
+	if e.err != nil && !e.done {
+		lines = append(lines, theme.Error.render(e.err.Error()))
+	}
+	if !e.done {
+		lines = append(lines, e.suggestionLines()...)
+	}
+	if e.showHelp {
+		lines = append(lines, e.c.helpLines()...)
+	}
+	return lines
+}
+
+// text returns the buffer as it's shown, masked for secrets.
+func (e *lineEditor) text() string {
+	if e.c.secret {
+		return strings.Repeat("*", len(e.buffer))
+	}
+	return string(e.buffer)
+}
+
+func (e *lineEditor) render() {
+	cursorCol := displayWidth(e.prompt) + e.cursor
+	if !e.c.secret {
+		cursorCol = displayWidth(e.prompt) + displayWidth(string(e.buffer[:e.cursor]))
+	}
+	e.screen.render(e.lines(), len(e.p.header), cursorCol)
+}
+
+// finish draws the final state of the input, without the help and the placeholder.
+func (e *lineEditor) finish() {
+	e.showHelp = false
+	e.done = true
+	e.render()
+}
@@ -0,0 +1,132 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineEditor(t *testing.T) {
+	t.Log("Editing")
+	{
+		// type "helo", move left, insert "l", go to the end, delete the last character with backspace, then type "o!"
+		res, err := AskForString("Greeting", WithReader(strings.NewReader("helo\x1b[Dl\x1b[F\x7fo!\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, "hello!", res)
+	}
+
+	t.Log("Home and delete")
+	{
+		res, err := AskForString("Greeting", WithReader(strings.NewReader("xhello\x01\x1b[3~\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, "hello", res)
+	}
+
+	t.Log("Default value")
+	{
+		res, err := AskForInt("Number", WithReader(strings.NewReader("\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithDefault(3))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res)
+	}
+
+	t.Log("Ctrl-C")
+	{
+		_, err := AskForString("Greeting", WithReader(strings.NewReader("hel\x03")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.True(t, errors.Is(err, ErrInterrupted))
+	}
+
+	t.Log("Ctrl-D")
+	{
+		_, err := AskForString("Greeting", WithReader(strings.NewReader("\x04")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.True(t, errors.Is(err, ErrEOF))
+	}
+
+	t.Log("Output")
+	{
+		var out bytes.Buffer
+		_, err := AskForString("Q", WithReader(strings.NewReader("a\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "\r\x1b[JQ : \r\x1b[4C"+"\r\x1b[JQ : a\r\x1b[5C"+"\r\x1b[JQ : a\r\x1b[5C"+"\r\x1b[J✓ Q: a\r\x1b[6C\r\n", out.String())
+	}
+}
+
+func TestHelp(t *testing.T) {
+	t.Log("Line mode")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Bundle ID", WithReader(strings.NewReader("?\ncom.app\n")), WithWriter(&out), WithTheme(PlainTheme),
+			WithHelp("The bundle ID of the app."), WithHelpURL("https://example.com/bundle-id"))
+		require.NoError(t, err)
+		require.Equal(t, "com.app", res)
+		require.Equal(t, "Bundle ID (? for help) : \n"+
+			"The bundle ID of the app.\n"+
+			"More information: https://example.com/bundle-id\n"+
+			"Bundle ID (? for help) : \n", out.String())
+	}
+
+	t.Log("Line mode - select")
+	{
+		res, err := SelectFromStrings("Scheme", []string{"App", "Tests"}, WithReader(strings.NewReader("?\n2\n")), WithWriter(&bytes.Buffer{}), WithHelp("The scheme to build."))
+		require.NoError(t, err)
+		require.Equal(t, "Tests", res)
+	}
+
+	t.Log("Line mode - no help")
+	{
+		res, err := AskForString("Question", WithReader(strings.NewReader("?\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, "?", res)
+	}
+
+	t.Log("TTY mode")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Bundle ID", WithReader(strings.NewReader("?com.app?\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme),
+			WithHelp("The bundle ID of the app."))
+		require.NoError(t, err)
+		require.Equal(t, "com.app?", res)
+		require.Contains(t, out.String(), "Bundle ID (? for help) : \r\nThe bundle ID of the app.")
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	t.Log("Line mode")
+	{
+		var out bytes.Buffer
+		_, err := AskForString("Bundle ID", WithReader(strings.NewReader("\n")), WithWriter(&out), WithTheme(PlainTheme), WithPlaceholder("com.company.app"))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+		require.Equal(t, "Bundle ID (e.g. com.company.app) : \n", out.String())
+	}
+
+	t.Log("Line mode - with default")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Bundle ID", WithReader(strings.NewReader("\n")), WithWriter(&out), WithTheme(PlainTheme), WithPlaceholder("com.company.app"), WithDefault("io.app"))
+		require.NoError(t, err)
+		require.Equal(t, "io.app", res)
+		require.Equal(t, "Bundle ID [io.app] (e.g. com.company.app) : \n", out.String())
+	}
+
+	t.Log("TTY mode")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Bundle ID", WithReader(strings.NewReader("a\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}),
+			WithTheme(Theme{Placeholder: NewStyle("2")}), WithPlaceholder("com.company.app"))
+		require.NoError(t, err)
+		require.Equal(t, "a", res)
+		// the placeholder is shown while the input is empty, with the cursor at its start
+		require.True(t, strings.HasPrefix(out.String(), "\r\x1b[JBundle ID : \x1b[2mcom.company.app\x1b[0m\r\x1b[12C"))
+		require.True(t, strings.HasSuffix(out.String(), "\r\x1b[JBundle ID : a\r\x1b[13C\r\x1b[J✓ Bundle ID: a\r\x1b[14C\r\n"))
+	}
+
+	t.Log("TTY mode - not returned")
+	{
+		var out bytes.Buffer
+		_, err := AskForString("Bundle ID", WithReader(strings.NewReader("\r\x03")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithPlaceholder("com.company.app"))
+		require.True(t, errors.Is(err, ErrInterrupted))
+		require.Contains(t, out.String(), "value must be specified")
+	}
+}
@@ -0,0 +1,289 @@
+package goinp
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//=======================================
+// Environment variables
+//=======================================
+
+// EnvVar is a key/value pair answered to AskForEnvVars.
+type EnvVar struct {
+	Key    string
+	Value  string
+	Secret bool
+}
+
+// EnvVars is an ordered list of environment variables.
+type EnvVars []EnvVar
+
+// Map returns the variables as a map of keys to values.
+func (v EnvVars) Map() map[string]string {
+	values := make(map[string]string, len(v))
+	for _, envVar := range v {
+		values[envVar.Key] = envVar.Value
+	}
+	return values
+}
+
+func (v EnvVars) keys() []string {
+	keys := make([]string, 0, len(v))
+	for _, envVar := range v {
+		keys = append(keys, envVar.Key)
+	}
+	return keys
+}
+
+// errIncompleteValue is returned by parseEnvVar for a quoted value continuing on the next line.
+var errIncompleteValue = errors.New("incomplete value")
+
+// AskForEnvVars asks for environment variables, one KEY=value pair per line until an empty answer.
+// The keys have to be valid POSIX variable names and can't be repeated. The values can be quoted like in a dotenv file:
+// Double quoted values support the \n, \" and \\ escapes, single quoted ones are taken literally, and both can span several lines, so a dotenv file can be pasted.
+// Comment lines (#) and the export keyword are ignored.
+// The validators run on every value. With WithSecretFlag, the user is asked whether each variable is a secret.
+// The number of variables can be limited with WithMinItems and WithMaxItems.
+func AskForEnvVars(messageToPrint string, opts ...Option) (EnvVars, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForEnvVars", questionOptions, listOptions, boolOptions, []string{"WithSecretFlag"}); err != nil {
+		return nil, err
+	}
+	return askForEnvVars(c, messageToPrint)
+}
+
+func askForEnvVars(c *config, messageToPrint string) (envVars EnvVars, err error) {
+	defer func() { c.finishQuestion(messageToPrint, strings.Join(envVars.keys(), ", "), err) }()
+
+	var defaultEnvVars EnvVars
+	if c.hasDefault {
+		if defaultEnvVars, err = c.defaultEnvVars(); err != nil {
+			return nil, err
+		}
+	}
+
+	theme := c.currentTheme()
+	question := theme.Prompt.render(messageToPrint)
+	if len(defaultEnvVars) > 0 {
+		question += " " + theme.DefaultHint.render("["+strings.Join(defaultEnvVars.keys(), ", ")+"]")
+	}
+	question += " " + theme.Help.render(c.message(MsgEnvVarFinishHint))
+
+	minItems := c.minItems
+	if minItems == 0 && !c.hasDefault && !c.optional {
+		minItems = 1
+	}
+
+	// answered lines and the lines of the variable being answered, redrawn above the current question in TTY mode
+	var answered, current []string
+	header := func() []string {
+		if c.console != nil {
+			return append(append([]string{question}, answered...), current...)
+		}
+		if len(envVars) == 0 && len(answered) == 0 {
+			return []string{question}
+		}
+		return nil
+	}
+
+	for c.maxItems == 0 || len(envVars) < c.maxItems {
+		p := linePrompt{
+			header: header(),
+			prompt: theme.Prompt.render(c.message(MsgListItem, len(envVars)+1)),
+			check: func(answer string) error {
+				if isEnvComment(answer) {
+					return nil
+				}
+				if _, err := c.parseEnvVar(answer, envVars); err != nil && err != errIncompleteValue {
+					return err
+				}
+				return nil
+			},
+		}
+		p.checkEmpty = func() error {
+			if len(envVars) >= minItems || (len(envVars) == 0 && c.hasDefault) {
+				return nil
+			}
+			return c.tooFewItems(minItems)
+		}
+
+		answer, err := c.askLine(p)
+		if err == ErrEOF && p.checkEmpty() == nil {
+			answer, err = "", nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			break
+		}
+		if isEnvComment(answer) {
+			answered = append(answered, c.renderPrompt(p)+answer)
+			continue
+		}
+
+		current = []string{c.renderPrompt(p) + answer}
+		envVar, err := c.parseEnvVar(answer, envVars)
+		for err == errIncompleteValue {
+			continuation := linePrompt{header: header(), prompt: theme.Help.render("..."), separator: " ", checkEmpty: func() error { return nil }}
+			var line string
+			if line, err = c.askLine(continuation); err == ErrEOF {
+				return nil, &ValidationError{Input: answer, Message: c.message(MsgListUnterminatedQuote), Err: ErrInvalidOption}
+			} else if err != nil {
+				return nil, err
+			}
+			answer += "\n" + line
+			current = append(current, c.renderPrompt(continuation)+line)
+			envVar, err = c.parseEnvVar(answer, envVars)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if c.secretFlag {
+			if envVar.Secret, err = c.askEnvVarSecret(header(), envVar.Key); err != nil {
+				return nil, err
+			}
+		}
+
+		envVars = append(envVars, envVar)
+		answered = append(answered, c.renderPrompt(p)+envVar.display())
+		current = nil
+	}
+
+	if len(envVars) == 0 && c.hasDefault {
+		return defaultEnvVars, nil
+	}
+	return envVars, nil
+}
+
+// askEnvVarSecret asks whether the variable is a secret, no by default.
+func (c *config) askEnvVarSecret(header []string, key string) (bool, error) {
+	vocabulary := c.currentBoolVocabulary()
+	p := linePrompt{
+		header:    header,
+		prompt:    c.currentTheme().Prompt.render(c.message(MsgEnvVarIsSecret, key)),
+		hint:      vocabulary.hint(true, false),
+		separator: ": ",
+		check: func(answer string) error {
+			_, err := vocabulary.Parse(answer)
+			return err
+		},
+		checkEmpty: func() error { return nil },
+	}
+
+	answer, err := c.askLine(p)
+	if err == ErrEOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if answer == "" {
+		return false, nil
+	}
+	return vocabulary.Parse(answer)
+}
+
+// display renders the variable as it's shown after it's answered, secrets are masked.
+func (v EnvVar) display() string {
+	switch {
+	case v.Secret:
+		return v.Key + "=" + secretMask
+	case strings.ContainsAny(v.Value, "\n\""):
+		return v.Key + "=" + strconv.Quote(v.Value)
+	}
+	return v.Key + "=" + v.Value
+}
+
+func isEnvComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+// parseEnvVar parses a KEY=value line (or lines, for a quoted value) and checks that the key is valid and not in existing.
+func (c *config) parseEnvVar(text string, existing EnvVars) (EnvVar, error) {
+	line := strings.TrimSpace(text)
+	line = strings.TrimPrefix(line, "export ")
+
+	idx := strings.Index(line, "=")
+	if idx == -1 {
+		return EnvVar{}, &ValidationError{Input: text, Message: c.message(MsgEnvVarFormat, text), Err: ErrInvalidOption}
+	}
+
+	key := strings.TrimSpace(line[:idx])
+	if !isEnvVarName(key) {
+		return EnvVar{}, &ValidationError{Input: text, Message: c.message(MsgEnvVarInvalidKey, key), Err: ErrInvalidOption}
+	}
+	for _, envVar := range existing {
+		if envVar.Key == key {
+			return EnvVar{}, &ValidationError{Input: text, Message: c.message(MsgEnvVarDuplicate, key), Err: ErrInvalidOption}
+		}
+	}
+
+	value, err := c.parseEnvValue(strings.TrimLeft(line[idx+1:], " \t"))
+	if err != nil {
+		return EnvVar{}, err
+	}
+	if err := c.validate(value); err != nil {
+		return EnvVar{}, err
+	}
+	return EnvVar{Key: key, Value: value}, nil
+}
+
+// parseEnvValue parses the value of a dotenv line, errIncompleteValue is returned if a quote isn't closed.
+func (c *config) parseEnvValue(text string) (string, error) {
+	if text == "" || (text[0] != '"' && text[0] != '\'') {
+		// unquoted, an inline comment has to be separated by whitespace
+		if idx := strings.Index(text, " #"); idx != -1 {
+			text = text[:idx]
+		}
+		return strings.TrimSpace(text), nil
+	}
+
+	quote := rune(text[0])
+	var value strings.Builder
+	escaped := false
+	for idx, r := range text[1:] {
+		switch {
+		case escaped:
+			switch r {
+			case 'n':
+				value.WriteRune('\n')
+			case '"', '\\':
+				value.WriteRune(r)
+			default:
+				value.WriteRune('\\')
+				value.WriteRune(r)
+			}
+			escaped = false
+		case r == '\\' && quote == '"':
+			escaped = true
+		case r == quote:
+			rest := strings.TrimSpace(text[idx+2:])
+			if rest != "" && !strings.HasPrefix(rest, "#") {
+				return "", &ValidationError{Input: text, Message: c.message(MsgEnvVarTrailing, rest), Err: ErrInvalidOption}
+			}
+			return value.String(), nil
+		default:
+			value.WriteRune(r)
+		}
+	}
+	return "", errIncompleteValue
+}
+
+// isEnvVarName checks the POSIX rules: letters, digits and underscores, not starting with a digit.
+func isEnvVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for idx, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && idx > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForEnvVars(t *testing.T) {
+	t.Log("Pairs until an empty answer")
+	{
+		var out bytes.Buffer
+		res, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("DEBUG=1\nAPP_NAME = My App\n\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, EnvVars{{Key: "DEBUG", Value: "1"}, {Key: "APP_NAME", Value: "My App"}}, res)
+		require.Equal(t, map[string]string{"DEBUG": "1", "APP_NAME": "My App"}, res.Map())
+		require.Equal(t, "Build environment (KEY=value, empty answer to finish)\nitem 1 : item 2 : item 3 : \n", out.String())
+	}
+
+	t.Log("Pasted dotenv file")
+	{
+		dotenv := `# signing
+export KEYSTORE_PATH=./release.jks # relative to the project
+KEYSTORE_ALIAS='my alias'
+CERTIFICATE="-----BEGIN-----
+abc\"def
+-----END-----"
+GREETING="hello\nworld"
+
+`
+		res, err := AskForEnvVars("Build environment", WithReader(strings.NewReader(dotenv)), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, EnvVars{
+			{Key: "KEYSTORE_PATH", Value: "./release.jks"},
+			{Key: "KEYSTORE_ALIAS", Value: "my alias"},
+			{Key: "CERTIFICATE", Value: "-----BEGIN-----\nabc\"def\n-----END-----"},
+			{Key: "GREETING", Value: "hello\nworld"},
+		}, res)
+	}
+
+	t.Log("Invalid key")
+	{
+		_, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("1ST=a\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid variable name: 1ST, should contain letters, digits and _, and not start with a digit")
+
+		_, err = AskForEnvVars("Build environment", WithReader(strings.NewReader("DEBUG\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "invalid variable: DEBUG, should be KEY=value")
+	}
+
+	t.Log("Duplicate key")
+	{
+		_, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("DEBUG=1\nDEBUG=2\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "duplicate variable: DEBUG")
+	}
+
+	t.Log("Unterminated quote")
+	{
+		_, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("NAME=\"abc\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "unterminated quote")
+	}
+
+	t.Log("Secret flag")
+	{
+		res, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("TOKEN=abc\ny\nDEBUG=1\n\n\n")), WithWriter(&bytes.Buffer{}), WithSecretFlag())
+		require.NoError(t, err)
+		require.Equal(t, EnvVars{{Key: "TOKEN", Value: "abc", Secret: true}, {Key: "DEBUG", Value: "1"}}, res)
+	}
+
+	t.Log("Default")
+	{
+		res, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(EnvVars{{Key: "DEBUG", Value: "1"}}))
+		require.NoError(t, err)
+		require.Equal(t, EnvVars{{Key: "DEBUG", Value: "1"}}, res)
+	}
+}
+
+func TestAskForEnvVarsTTY(t *testing.T) {
+	var out bytes.Buffer
+	res, err := AskForEnvVars("Build environment", WithReader(strings.NewReader("TOKEN=\"a\rb\"\ryes\rTOKEN=c\r\x7f\x7f\x7f\x7f\x7f\x7f\x7f\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithSecretFlag())
+	require.NoError(t, err)
+	require.Equal(t, EnvVars{{Key: "TOKEN", Value: "a\nb", Secret: true}}, res)
+	require.Contains(t, out.String(), "item 1 : TOKEN=\"a\r\n... b\"\r\nIs TOKEN a secret? [yes/NO]: ")
+	require.Contains(t, out.String(), "item 1 : TOKEN=********\r\nitem 2 : TOKEN=c\r\nduplicate variable: TOKEN")
+	require.Equal(t, "✓ Build environment: TOKEN\n", lastFrame(out.String()))
+}
@@ -0,0 +1,93 @@
+package goinp
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Sentinel errors returned by the askers, use errors.Is to check for them.
+// Their messages are localised, see RegisterCatalog.
+// ErrInterrupted, ErrEOF and ErrConfirmationRequired mean the user can't (or doesn't want to) answer, the caller should abort.
+// ErrEmptyInput, ErrInvalidOption and ErrOutOfRange are returned wrapped into a *ValidationError, the caller might ask the question again.
+var (
+	// ErrInterrupted is returned when the user aborts the question with Ctrl-C.
+	ErrInterrupted error = &messageError{MsgErrInterrupted}
+	// ErrEOF is returned when the input ends before an answer could be read.
+	ErrEOF error = &messageError{MsgErrEOF}
+	// ErrEmptyInput is returned when the answer is empty and the question has no default value.
+	ErrEmptyInput error = &messageError{MsgErrEmptyInput}
+	// ErrInvalidOption is returned when the answer doesn't match any of the accepted options.
+	ErrInvalidOption error = &messageError{MsgErrInvalidOption}
+	// ErrOutOfRange is returned when a numeric answer is outside of the accepted range.
+	ErrOutOfRange error = &messageError{MsgErrOutOfRange}
+	// ErrConfirmationRequired is returned when a destructive action can't be confirmed,
+	// because the input is not interactive and the caller didn't provide an override.
+	ErrConfirmationRequired error = &messageError{MsgErrConfirmationRequired}
+)
+
+// ValidationError is returned when an answer was read, but it was rejected.
+// Err is the cause: either one of the sentinel errors or the error of the parser which rejected the answer.
+type ValidationError struct {
+	Input   string
+	Message string
+	Err     error
+}
+
+// Error ...
+func (e *ValidationError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return message(MsgInvalidInput, e.Input)
+}
+
+// Unwrap ...
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	keyCtrlC = '\x03'
+	keyCtrlD = '\x04'
+)
+
+// readError is a failed read of the answer, with a localised message. It wraps the reader's error.
+type readError struct {
+	err error
+}
+
+func (e *readError) Error() string {
+	return message(MsgReadFailed, e.err)
+}
+
+// Unwrap ...
+func (e *readError) Unwrap() error {
+	return e.err
+}
+
+// readLine reads a single line from the reader, without the line ending.
+// A last line which isn't closed by a line ending is still returned,
+// ErrEOF is only returned if the input ended before anything could be read.
+// A Ctrl-C character in the line (sent by terminals in raw mode) results in ErrInterrupted.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", &readError{err: err}
+	}
+	if err == io.EOF && line == "" {
+		return "", ErrEOF
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if strings.ContainsRune(line, keyCtrlC) {
+		return "", ErrInterrupted
+	}
+	if strings.ContainsRune(line, keyCtrlD) && strings.Trim(line, string(keyCtrlD)) == "" {
+		return "", ErrEOF
+	}
+	return line, nil
+}
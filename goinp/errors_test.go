@@ -0,0 +1,100 @@
+package goinp
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskerErrors(t *testing.T) {
+	t.Log("Read error")
+	{
+		errRead := errors.New("connection reset")
+		_, err := AskForString("Enter some text", WithReader(iotest.ErrReader(errRead)), WithWriter(&strings.Builder{}))
+		require.True(t, errors.Is(err, errRead))
+		require.EqualError(t, err, "failed to get input - read failed with error: connection reset")
+
+		_, err = AskForString("Enter some text", WithReader(iotest.ErrReader(errRead)), WithWriter(&strings.Builder{}), withConsole(fakeConsole{80, 24}))
+		require.True(t, errors.Is(err, errRead))
+	}
+
+	t.Log("EOF, NO default value")
+	{
+		_, err := AskForStringFromReader("Enter some text", strings.NewReader(""))
+		require.True(t, errors.Is(err, ErrEOF))
+		require.False(t, errors.Is(err, ErrEmptyInput))
+	}
+
+	t.Log("Empty line, NO default value")
+	{
+		_, err := AskForStringFromReader("Enter some text", strings.NewReader("\n"))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+		require.False(t, errors.Is(err, ErrEOF))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+	}
+
+	t.Log("Ctrl-C")
+	{
+		_, err := AskForStringFromReader("Enter some text", strings.NewReader("abc\x03\n"))
+		require.True(t, errors.Is(err, ErrInterrupted))
+
+		_, err = AskForBoolFromReaderWithDefaultValue("Yes or no?", true, strings.NewReader("\x03"))
+		require.True(t, errors.Is(err, ErrInterrupted))
+	}
+
+	t.Log("Ctrl-D")
+	{
+		_, err := AskForStringFromReader("Enter some text", strings.NewReader("\x04\n"))
+		require.True(t, errors.Is(err, ErrEOF))
+	}
+
+	t.Log("Invalid number")
+	{
+		_, err := AskForIntFromReader("Enter a number", strings.NewReader("abc"))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, "abc", validationErr.Input)
+
+		var numErr *strconv.NumError
+		require.True(t, errors.As(err, &numErr))
+	}
+
+	t.Log("Invalid bool")
+	{
+		_, err := AskForBoolFromReaderWithDefaultValue("Yes or no?", true, strings.NewReader("maybe"))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, "maybe", validationErr.Input)
+	}
+
+	t.Log("Select - not a number")
+	{
+		_, err := SelectFromStringsFromReader("Select something", []string{"first", "second"}, strings.NewReader("first"))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid option: first is not a number")
+	}
+
+	t.Log("Select - out of range")
+	{
+		_, err := SelectFromStringsFromReader("Select something", []string{"first", "second"}, strings.NewReader("3"))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+
+		_, err = SelectFromStringsFromReaderWithDefault("Select something", 1, []string{"first", "second"}, strings.NewReader("0"))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+	}
+
+	t.Log("Optional input - empty, non-optional")
+	{
+		_, _, err := testAskOptionWrapper("", false, "\n")
+		require.True(t, errors.Is(err, ErrEmptyInput))
+		require.EqualError(t, err, "value must be specified")
+	}
+}
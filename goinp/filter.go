@@ -0,0 +1,23 @@
+package goinp
+
+import (
+	"unicode"
+)
+
+// CharFilter reports whether a character is accepted in the answer, see WithCharFilter.
+type CharFilter func(r rune) bool
+
+// IntegerChars accepts digits and signs, it's the default filter of int questions in TTY mode.
+func IntegerChars(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '-' || r == '+'
+}
+
+// HexChars accepts hexadecimal digits.
+func HexChars(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+// IdentifierChars accepts anything but whitespace.
+func IdentifierChars(r rune) bool {
+	return !unicode.IsSpace(r)
+}
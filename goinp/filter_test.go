@@ -0,0 +1,100 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharFilters(t *testing.T) {
+	require.True(t, IntegerChars('7'))
+	require.True(t, IntegerChars('-'))
+	require.False(t, IntegerChars('a'))
+
+	require.True(t, HexChars('F'))
+	require.False(t, HexChars('g'))
+
+	require.True(t, IdentifierChars('_'))
+	require.False(t, IdentifierChars(' '))
+}
+
+func TestCharFilterTTY(t *testing.T) {
+	t.Log("Int questions accept digits and signs only")
+	{
+		res, err := AskForInt("Jobs", WithReader(strings.NewReader("-1a2\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, int64(-12), res)
+	}
+
+	t.Log("Custom filter")
+	{
+		res, err := AskForString("Color", WithReader(strings.NewReader("ff 00 zz\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithCharFilter(HexChars))
+		require.NoError(t, err)
+		require.Equal(t, "ff00", res)
+	}
+}
+
+func TestCharFilterLineMode(t *testing.T) {
+	_, err := AskForString("Identifier", WithReader(strings.NewReader("my id")), WithWriter(&bytes.Buffer{}), WithCharFilter(IdentifierChars))
+	require.True(t, errors.Is(err, ErrInvalidOption))
+	require.EqualError(t, err, "invalid character: ' '")
+}
+
+func TestLiveValidation(t *testing.T) {
+	atMost10 := func(answer string) error {
+		value, err := strconv.Atoi(answer)
+		if err != nil || value > 10 {
+			return fmt.Errorf("should be at most 10")
+		}
+		return nil
+	}
+
+	t.Log("Enter is disabled while the answer is invalid")
+	{
+		var out bytes.Buffer
+		// "12" is rejected, then corrected to "1"
+		res, err := AskForInt("Jobs", WithReader(strings.NewReader("12\r\x7f\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithValidator(atMost10))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res)
+		require.Contains(t, out.String(), "Jobs : 12\r\nshould be at most 10")
+	}
+
+	t.Log("The error is shown while typing")
+	{
+		var out bytes.Buffer
+		_, err := AskForBool("Deploy?", WithReader(strings.NewReader("ye\x03")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.True(t, errors.Is(err, ErrInterrupted))
+		require.Contains(t, out.String(), "Deploy? [yes/no] : ye\r\ninvalid option: ye, accepted values: yes, y, no, n")
+
+		out.Reset()
+		_, err = AskForInt("Jobs", WithReader(strings.NewReader("-\x03")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.True(t, errors.Is(err, ErrInterrupted))
+		require.Contains(t, out.String(), "Jobs : -\r\ninvalid number: -")
+
+		// the parser's error is still wrapped
+		_, err = AskForInt("Jobs", WithReader(strings.NewReader("-\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, strconv.ErrSyntax))
+		require.EqualError(t, err, "invalid number: -")
+	}
+
+	t.Log("Empty answer without default")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Name", WithReader(strings.NewReader("\rbob\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "bob", res)
+		require.Contains(t, out.String(), "Name : \r\nvalue must be specified")
+	}
+
+	t.Log("Select")
+	{
+		res, err := SelectFromStrings("Scheme", []string{"App", "Tests"}, WithReader(strings.NewReader("3\r\x7f2\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, "Tests", res)
+	}
+}
@@ -2,7 +2,6 @@ package goinp
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,53 +14,220 @@ import (
 )
 
 //=======================================
-// String
+// Line input
 //=======================================
 
-// AskForStringFromReaderWithDefault ...
-func AskForStringFromReaderWithDefault(messageToPrint, defaultValue string, inputReader io.Reader) (string, error) {
-	defer fmt.Println()
-
-	scanner := bufio.NewScanner(inputReader)
+// linePrompt describes how a question answered with a line of text is printed.
+type linePrompt struct {
+	// header lines are printed before the prompt, like the options of a select question.
+	header []string
+	// prompt is the already rendered question.
+	prompt string
+	// hint is printed in brackets after the prompt, usually the default value.
+	hint string
+	// separator is printed between the prompt and the answer, " : " if empty.
+	separator string
+	// check validates a non-empty answer. In TTY mode it runs while typing, and Enter is disabled while it fails.
+	check func(answer string) error
+	// filter is the default character filter of the question type, used in TTY mode if WithCharFilter is not set.
+	filter CharFilter
+	// checkEmpty, if set, validates the empty answer instead of the default value / Optional rule.
+	checkEmpty func() error
+	// helpKey is set if "?" is already listed in the hint as one of the answers, then the help hint is not printed.
+	helpKey bool
+}
 
-	if defaultValue == "" {
-		fmt.Printf("%s : ", messageToPrint)
+// askLine prints the prompt and reads a line of answer, with the trailing spaces trimmed.
+// An empty answer is returned as is if the question has a default value or is optional, the caller is responsible
+// for replacing it with the default. Otherwise it's rejected with ErrEmptyInput.
+// A non-empty answer is returned only if it passes the checks (see checkAnswer).
+// The question has to be closed by finishQuestion.
+func (c *config) askLine(p linePrompt) (string, error) {
+	c.asked = true
+	prompt := c.renderPrompt(p)
+
+	var answer string
+	var err error
+	if c.console != nil {
+		answer, err = c.editLine(p, prompt)
 	} else {
-		fmt.Printf("%s [%s] : ", messageToPrint, defaultValue)
+		for _, line := range p.header {
+			c.println(line)
+		}
+		answer, err = c.readAnswerLine(prompt)
 	}
+	if err != nil && !(err == ErrEOF && c.hasDefault) {
+		return "", err
+	}
+	answer = strings.TrimRight(answer, " ")
 
-	scannedText := ""
-	if scanner.Scan() {
-		scannedText = scanner.Text()
-		scannedText = strings.TrimRight(scannedText, " ")
+	if err := c.checkAnswer(p, answer); err != nil {
+		return "", err
 	}
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("failed to get input - scanner failed wit error: %s", err)
+	return answer, nil
+}
+
+// checkAnswer validates the (trimmed) answer: an empty answer is only accepted if the question has a default value
+// or is optional, a non-empty one has to pass the character filter set by WithCharFilter and the question's check.
+func (c *config) checkAnswer(p linePrompt, answer string) error {
+	if answer == "" {
+		if p.checkEmpty != nil {
+			return p.checkEmpty()
+		}
+		if !c.hasDefault && !c.optional {
+			return &ValidationError{Err: ErrEmptyInput}
+		}
+		return nil
 	}
 
-	if scannedText == "" {
-		if defaultValue != "" {
-			return defaultValue, nil
+	if c.charFilter != nil {
+		for _, r := range answer {
+			if !c.charFilter(r) {
+				return &ValidationError{Input: answer, Message: c.message(MsgInvalidCharacter, r), Err: ErrInvalidOption}
+			}
 		}
-		return "", errors.New("failed to get input - scanner failed")
 	}
+	if p.check != nil {
+		return p.check(answer)
+	}
+	return nil
+}
+
+// renderPrompt renders the prompt with the hint, the placeholder (in line mode), the help hint (if the question has help) and the separator.
+func (c *config) renderPrompt(p linePrompt) string {
+	theme := c.currentTheme()
+
+	prompt := p.prompt
+	if p.hint != "" {
+		prompt += " " + theme.DefaultHint.render("["+p.hint+"]")
+	}
+	if c.placeholder != "" && c.console == nil {
+		prompt += " " + theme.Placeholder.render(c.message(MsgPlaceholderHint, c.placeholder))
+	}
+	if c.hasHelp() && !p.helpKey {
+		prompt += " " + theme.Help.render(c.message(MsgHelpHint))
+	}
+
+	if p.separator == "" {
+		return prompt + " : "
+	}
+	return prompt + p.separator
+}
 
-	return scannedText, nil
+// readAnswerLine prints the prompt and reads a line of answer in line mode.
+// If the question has help and the answer is "?", the help is printed and the question is asked again.
+// If the question has suggestions, they are listed first and an answer ending with Tab is completed (see completeLine).
+func (c *config) readAnswerLine(prompt string) (string, error) {
+	if line := c.suggestionsLine(c.suggestions("")); line != "" {
+		c.println(line)
+	}
+
+	for {
+		c.printf("%s", prompt)
+
+		answer, err := readLine(c.input())
+		if err != nil {
+			return "", err
+		}
+		if c.completer != nil && strings.HasSuffix(answer, "\t") {
+			if completed, ok := c.completeLine(prompt, strings.TrimRight(answer, "\t")); ok {
+				return completed, nil
+			}
+			continue
+		}
+		if !c.hasHelp() || strings.TrimSpace(answer) != "?" {
+			return answer, nil
+		}
+
+		c.println()
+		for _, line := range c.helpLines() {
+			c.println(line)
+		}
+	}
+}
+
+// helpLines returns the rendered help text and documentation URL of the question.
+func (c *config) helpLines() []string {
+	theme := c.currentTheme()
+
+	var lines []string
+	if help := strings.TrimSpace(c.help); help != "" {
+		for _, line := range strings.Split(help, "\n") {
+			lines = append(lines, theme.Help.render(line))
+		}
+	}
+	if c.helpURL != "" {
+		lines = append(lines, theme.Help.render(c.message(MsgHelpURL, c.helpURL)))
+	}
+	return lines
+}
+
+//=======================================
+// String
+//=======================================
+
+// AskForString asks for a string.
+// An empty answer is replaced by the default value (see WithDefault), accepted if the question is Optional
+// and rejected with ErrEmptyInput otherwise.
+func AskForString(messageToPrint string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForString", questionOptions, textOptions); err != nil {
+		return "", err
+	}
+	return askForString(c, messageToPrint)
+}
+
+func askForString(c *config, messageToPrint string) (answer string, err error) {
+	defer func() { c.finishQuestion(messageToPrint, answer, err) }()
+
+	p := linePrompt{prompt: c.currentTheme().Prompt.render(messageToPrint)}
+
+	defaultValue := ""
+	if c.hasDefault {
+		if defaultValue, err = c.defaultString(); err != nil {
+			return "", err
+		}
+		p.hint = defaultValue
+	}
+	p.check = c.validate
+
+	answer, err = c.askLine(p)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// defaultStringOptions converts the default value of the deprecated functions, where an empty string means no default.
+func defaultStringOptions(defaultValue string, opts ...Option) []Option {
+	if defaultValue == "" {
+		return opts
+	}
+	return append(opts, WithDefault(defaultValue))
+}
+
+// AskForStringFromReaderWithDefault ...
+//
+// Deprecated: use AskForString with WithReader and WithDefault.
+func AskForStringFromReaderWithDefault(messageToPrint, defaultValue string, inputReader io.Reader) (string, error) {
+	return AskForString(messageToPrint, defaultStringOptions(defaultValue, WithReader(inputReader))...)
 }
 
 // AskForStringFromReader ...
+//
+// Deprecated: use AskForString with WithReader.
 func AskForStringFromReader(messageToPrint string, inputReader io.Reader) (string, error) {
-	return AskForStringFromReaderWithDefault(messageToPrint, "", inputReader)
+	return AskForString(messageToPrint, WithReader(inputReader))
 }
 
 // AskForStringWithDefault ...
+//
+// Deprecated: use AskForString with WithDefault.
 func AskForStringWithDefault(messageToPrint, defaultValue string) (string, error) {
-	return AskForStringFromReaderWithDefault(messageToPrint, defaultValue, os.Stdin)
-}
-
-// AskForString ...
-func AskForString(messageToPrint string) (string, error) {
-	return AskForStringFromReader(messageToPrint, os.Stdin)
+	return AskForString(messageToPrint, defaultStringOptions(defaultValue)...)
 }
 
 // WriteToTerminalInputBuffer prints a text to the terminal console which can be used as an input for a question or can be cleared out
@@ -90,7 +256,7 @@ func askForOptionalInput(defaultValue string, optional bool, reader io.Reader, w
 		}
 	}
 
-	input, err := r.ReadString('\n')
+	input, err := readLine(r)
 	if err != nil {
 		return "", err
 	}
@@ -98,7 +264,7 @@ func askForOptionalInput(defaultValue string, optional bool, reader io.Reader, w
 	input = strings.TrimSpace(input)
 
 	if !optional && input == "" {
-		return "", fmt.Errorf("value must be specified")
+		return "", &ValidationError{Err: ErrEmptyInput}
 	}
 
 	return input, nil
@@ -113,15 +279,19 @@ func AskForOptionalInput(defaultValue string, optional bool) (string, error) {
 // Path
 //=======================================
 
-// AskForPathFromReaderWithDefault asks for a path. The difference between this
+// AskForPath asks for a path. The difference between this
 //
 //	and the generic "AskForString..." functions is that this'll
 //	clean up the input. For example, if the user drag-and-drops a file/dir
 //	for the input then the input might include back-slash escapes for
 //	spaces in the path - these will be removed, so the
 //	returned path will be "path/with space" instead of "path/with\ space".
-func AskForPathFromReaderWithDefault(messageToPrint, defaultValue string, inputReader io.Reader) (string, error) {
-	str, err := AskForStringFromReaderWithDefault(messageToPrint, defaultValue, inputReader)
+func AskForPath(messageToPrint string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForPath", questionOptions, textOptions); err != nil {
+		return "", err
+	}
+	str, err := askForString(c, messageToPrint)
 	if err != nil {
 		return "", err
 	}
@@ -129,180 +299,336 @@ func AskForPathFromReaderWithDefault(messageToPrint, defaultValue string, inputR
 	return strings.Replace(str, "\\", "", -1), nil
 }
 
+// AskForPathFromReaderWithDefault ...
+//
+// Deprecated: use AskForPath with WithReader and WithDefault.
+func AskForPathFromReaderWithDefault(messageToPrint, defaultValue string, inputReader io.Reader) (string, error) {
+	return AskForPath(messageToPrint, defaultStringOptions(defaultValue, WithReader(inputReader))...)
+}
+
 // AskForPathFromReader ...
+//
+// Deprecated: use AskForPath with WithReader.
 func AskForPathFromReader(messageToPrint string, inputReader io.Reader) (string, error) {
-	return AskForPathFromReaderWithDefault(messageToPrint, "", inputReader)
+	return AskForPath(messageToPrint, WithReader(inputReader))
 }
 
 // AskForPathWithDefault ...
+//
+// Deprecated: use AskForPath with WithDefault.
 func AskForPathWithDefault(messageToPrint, defaultValue string) (string, error) {
-	return AskForPathFromReaderWithDefault(messageToPrint, defaultValue, os.Stdin)
-}
-
-// AskForPath ...
-func AskForPath(messageToPrint string) (string, error) {
-	return AskForPathFromReader(messageToPrint, os.Stdin)
+	return AskForPath(messageToPrint, defaultStringOptions(defaultValue)...)
 }
 
 //=======================================
 // Int
 //=======================================
 
-// AskForIntFromReaderWithDefault ...
-func AskForIntFromReaderWithDefault(messageToPrint string, defaultValue int, inputReader io.Reader) (int64, error) {
-	userInputStr, err := AskForStringFromReaderWithDefault(messageToPrint, fmt.Sprintf("%d", defaultValue), inputReader)
-	if err != nil {
+// AskForInt asks for a 64 bit integer.
+// Unlike the deprecated functions, there is no default value unless WithDefault is used.
+func AskForInt(messageToPrint string, opts ...Option) (int64, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForInt", questionOptions, textOptions); err != nil {
 		return 0, err
 	}
-	return strconv.ParseInt(userInputStr, 10, 64)
+	return askForInt(c, messageToPrint)
 }
 
-// AskForIntFromReader ...
-func AskForIntFromReader(messageToPrint string, inputReader io.Reader) (int64, error) {
-	userInputStr, err := AskForStringFromReader(messageToPrint, inputReader)
+func askForInt(c *config, messageToPrint string) (value int64, err error) {
+	// the summary is empty for an empty Optional answer, like the string question's
+	summary := ""
+	defer func() { c.finishQuestion(messageToPrint, summary, err) }()
+
+	p := linePrompt{prompt: c.currentTheme().Prompt.render(messageToPrint)}
+
+	var defaultValue int64
+	if c.hasDefault {
+		if defaultValue, err = c.defaultInt(); err != nil {
+			return 0, err
+		}
+		p.hint = strconv.FormatInt(defaultValue, 10)
+	}
+	p.filter = IntegerChars
+	p.check = func(answer string) error {
+		if _, err := c.parseInt(answer); err != nil {
+			return err
+		}
+		return c.validate(answer)
+	}
+
+	answer, err := c.askLine(p)
 	if err != nil {
 		return 0, err
 	}
-	return strconv.ParseInt(userInputStr, 10, 64)
+	if answer == "" {
+		summary = p.hint
+		return defaultValue, nil
+	}
+	if value, err = c.parseInt(answer); err != nil {
+		return 0, err
+	}
+	summary = strconv.FormatInt(value, 10)
+	return value, nil
 }
 
-// AskForIntWithDeafult ...
-func AskForIntWithDeafult(messageToPrint string, defaultValue int) (int64, error) {
-	return AskForIntFromReaderWithDefault(messageToPrint, defaultValue, os.Stdin)
+func (c *config) parseInt(userInputStr string) (int64, error) {
+	value, err := strconv.ParseInt(userInputStr, 10, 64)
+	if err != nil {
+		return 0, &ValidationError{Input: userInputStr, Message: c.message(MsgIntInvalid, userInputStr), Err: err}
+	}
+	return value, nil
 }
 
-// AskForInt ...
-func AskForInt(messageToPrint string) (int64, error) {
-	return AskForIntFromReader(messageToPrint, os.Stdin)
+// AskForIntFromReaderWithDefault ...
+//
+// Deprecated: use AskForInt with WithReader and WithDefault.
+func AskForIntFromReaderWithDefault(messageToPrint string, defaultValue int, inputReader io.Reader) (int64, error) {
+	return AskForInt(messageToPrint, WithReader(inputReader), WithDefault(defaultValue))
+}
+
+// AskForIntFromReader ...
+//
+// Deprecated: use AskForInt with WithReader.
+func AskForIntFromReader(messageToPrint string, inputReader io.Reader) (int64, error) {
+	return AskForInt(messageToPrint, WithReader(inputReader))
+}
+
+// AskForIntWithDeafult ...
+//
+// Deprecated: use AskForInt with WithDefault.
+func AskForIntWithDeafult(messageToPrint string, defaultValue int) (int64, error) {
+	return AskForInt(messageToPrint, WithDefault(defaultValue))
 }
 
 //=======================================
 // Bool
 //=======================================
 
-// ParseBool ...
+// ParseBool interprets the answer using the vocabulary set by SetBoolVocabulary (the vocabulary of the current locale by default).
 func ParseBool(userInputStr string) (bool, error) {
-	if userInputStr == "" {
-		return false, errors.New("no string to parse")
-	}
-	userInputStr = strings.TrimSpace(userInputStr)
+	return currentBoolVocabulary().Parse(userInputStr)
+}
 
-	lowercased := strings.ToLower(userInputStr)
-	if lowercased == "yes" || lowercased == "y" {
-		return true, nil
-	}
-	if lowercased == "no" || lowercased == "n" {
-		return false, nil
+// AskForBool asks a yes/no question, the accepted answers are defined by the vocabulary (see WithBoolVocabulary).
+// With Keypress the question is answered by a single key press in TTY mode.
+func AskForBool(messageToPrint string, opts ...Option) (bool, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForBool", questionOptions, boolOptions); err != nil {
+		return false, err
 	}
-	return strconv.ParseBool(lowercased)
+	return askForBool(c, messageToPrint)
 }
 
-// AskForBoolFromReaderWithDefaultValue ...
-func AskForBoolFromReaderWithDefaultValue(messageToPrint string, defaultValue bool, inputReader io.Reader) (bool, error) {
-	defer fmt.Println()
+func askForBool(c *config, messageToPrint string) (value bool, err error) {
+	vocabulary := c.currentBoolVocabulary()
+	// the summary is empty for an empty Optional answer, like the string question's
+	summary := ""
+	defer func() { c.finishQuestion(messageToPrint, summary, err) }()
+
+	p := linePrompt{prompt: c.currentTheme().Prompt.render(messageToPrint)}
 
-	keywordYes := "yes"
-	keywordNo := "no"
-	if defaultValue == true {
-		keywordYes = "YES"
+	defaultValue := false
+	if c.hasDefault {
+		if defaultValue, err = c.defaultBool(); err != nil {
+			return false, err
+		}
+		p.hint = vocabulary.hint(true, defaultValue)
+		p.separator = ": "
 	} else {
-		keywordNo = "NO"
+		p.hint = vocabulary.hint(false, false)
+	}
+	p.check = func(answer string) error {
+		if _, err := vocabulary.Parse(answer); err != nil {
+			return err
+		}
+		return c.validate(answer)
 	}
-	fmt.Printf("%s [%s/%s]: ", messageToPrint, keywordYes, keywordNo)
 
-	scanner := bufio.NewScanner(inputReader)
-	scannedText := ""
-	if scanner.Scan() {
-		scannedText = scanner.Text()
+	var answer string
+	if c.keypress && c.console != nil {
+		answer, err = c.askKeypress(p)
+	} else {
+		answer, err = c.askLine(p)
 	}
-	if err := scanner.Err(); err != nil {
-		return false, fmt.Errorf("failed to get input - scanner failed wit error: %s", err)
+	if err != nil {
+		return false, err
 	}
-
-	if scannedText == "" {
+	if answer == "" {
+		if c.hasDefault {
+			summary = vocabulary.word(defaultValue)
+		}
 		return defaultValue, nil
 	}
-	return ParseBool(scannedText)
+	if value, err = vocabulary.Parse(answer); err != nil {
+		return false, err
+	}
+	summary = vocabulary.word(value)
+	return value, nil
+}
+
+// AskForBoolFromReaderWithDefaultValue ...
+//
+// Deprecated: use AskForBool with WithReader and WithDefault.
+func AskForBoolFromReaderWithDefaultValue(messageToPrint string, defaultValue bool, inputReader io.Reader) (bool, error) {
+	return AskForBool(messageToPrint, WithReader(inputReader), WithDefault(defaultValue))
 }
 
 // AskForBoolFromReader ...
+//
+// Deprecated: use AskForBool with WithReader.
 func AskForBoolFromReader(messageToPrint string, inputReader io.Reader) (bool, error) {
-	userInputStr, err := AskForStringFromReader(messageToPrint+" [yes/no]", inputReader)
-	if err != nil {
-		return false, err
-	}
-
-	return ParseBool(userInputStr)
+	return AskForBool(messageToPrint, WithReader(inputReader))
 }
 
 // AskForBoolWithDefault ...
+//
+// Deprecated: use AskForBool with WithDefault.
 func AskForBoolWithDefault(messageToPrint string, defaultValue bool) (bool, error) {
-	return AskForBoolFromReaderWithDefaultValue(messageToPrint, defaultValue, os.Stdin)
-}
-
-// AskForBool ...
-func AskForBool(messageToPrint string) (bool, error) {
-	return AskForBoolFromReader(messageToPrint, os.Stdin)
+	return AskForBool(messageToPrint, WithDefault(defaultValue))
 }
 
 //=======================================
 // Select
 //=======================================
 
-// SelectFromStringsFromReaderWithDefault ...
-func SelectFromStringsFromReaderWithDefault(messageToPrint string, defaultValue int, options []string, inputReader io.Reader) (string, error) {
-	fmt.Printf("%s\n", messageToPrint)
-	fmt.Println("Please select from the list:")
+// SelectFromStrings asks the user to select one of the options, by typing in its number.
+// The default value can be given either as the option itself or as the option's number (see WithDefault).
+func SelectFromStrings(messageToPrint string, options []string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("SelectFromStrings", questionOptions, []string{"WithPageSize"}); err != nil {
+		return "", err
+	}
+	return selectFromStrings(c, messageToPrint, options)
+}
+
+func selectFromStrings(c *config, messageToPrint string, options []string) (string, error) {
+	selected, err := selectFrom(c, messageToPrint, stringOptions(options))
+	return selected.Value, err
+}
+
+func stringOptions(options []string) []SelectOption {
+	selectOptions := make([]SelectOption, len(options))
 	for idx, anOption := range options {
-		fmt.Printf("[%d] : %s\n", idx+1, anOption)
+		selectOptions[idx] = SelectOption{Value: anOption}
 	}
+	return selectOptions
+}
 
-	selectedOptionNum, err := AskForIntFromReaderWithDefault(
-		"(type in the option's number, then hit Enter)",
-		defaultValue,
-		inputReader,
-	)
-	if err != nil {
-		return "", err
+// SelectOrCreate asks the user to select one of the options like SelectFromStrings, or to enter the value manually
+// by selecting the additional "Other (enter manually)" option. custom is true if the entered value isn't one of the options.
+// The validators only run on the entered value.
+func SelectOrCreate(messageToPrint string, options []string, opts ...Option) (value string, custom bool, err error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("SelectOrCreate", questionOptions, textOptions, []string{"WithPageSize"}); err != nil {
+		return "", false, err
+	}
+	// the manual entry shares the buffered input
+	c.input()
+	other := c.message(MsgSelectOther)
+
+	selectConfig := *c
+	selectConfig.validators = nil
+	selected, err := chooseFrom(&selectConfig, messageToPrint, stringOptions(append(append([]string{}, options...), other)))
+	if err != nil || selected.Value != other {
+		selectConfig.finishQuestion(messageToPrint, selected.label(), err)
+		return selected.Value, false, err
 	}
 
-	if selectedOptionNum < 1 {
-		return "", fmt.Errorf("invalid option: You entered a number less than 1")
+	stringConfig := *c
+	stringConfig.hasDefault = false
+	stringConfig.optional = false
+	if selectConfig.screen != nil {
+		// in TTY mode the value is asked in place of the options, only its summary is left
+		stringConfig.screen = selectConfig.screen
+	} else {
+		selectConfig.finishQuestion(messageToPrint, selected.label(), nil)
 	}
-	if selectedOptionNum > int64(len(options)) {
-		return "", fmt.Errorf("invalid option: You entered a number greater than the last option's number")
+	if value, err = askForString(&stringConfig, messageToPrint); err != nil {
+		return "", false, err
 	}
-	return options[selectedOptionNum-1], nil
+	for _, anOption := range options {
+		if anOption == value {
+			return value, false, nil
+		}
+	}
+	return value, true, nil
 }
 
-// SelectFromStringsFromReader ...
-func SelectFromStringsFromReader(messageToPrint string, options []string, inputReader io.Reader) (string, error) {
-	fmt.Printf("%s\n", messageToPrint)
-	fmt.Println("Please select from the list:")
-	for idx, anOption := range options {
-		fmt.Printf("[%d] : %s\n", idx+1, anOption)
+// defaultOption returns the number of the default option, 0 if there is no default.
+func (c *config) defaultOption(options []string) (int, error) {
+	if !c.hasDefault {
+		return 0, nil
+	}
+
+	if value, ok := c.defaultValue.(string); ok {
+		for idx, anOption := range options {
+			if anOption == value {
+				return idx + 1, nil
+			}
+		}
+		return 0, fmt.Errorf("invalid default value (%s), not one of the options", value)
+	}
+
+	value, err := c.defaultInt()
+	if err != nil {
+		return 0, fmt.Errorf("invalid default value (%v) for a select question, should be an option or an option's number", c.defaultValue)
 	}
+	return int(value), nil
+}
 
-	selectedOptionNum, err := AskForIntFromReader("(type in the option's number, then hit Enter)", inputReader)
+func selectOption(c *config, options []string, userInputStr string) (string, error) {
+	selectedOptionNum, err := selectNumber(c, len(options), userInputStr)
 	if err != nil {
 		return "", err
 	}
+	return options[selectedOptionNum-1], nil
+}
+
+// selectNumber parses the number of the selected option, between 1 and count.
+func selectNumber(c *config, count int, userInputStr string) (int, error) {
+	selectedOptionNum, err := strconv.ParseInt(userInputStr, 10, 64)
+	if err != nil {
+		return 0, &ValidationError{
+			Input:   userInputStr,
+			Message: c.message(MsgOptionNotANumber, userInputStr),
+			Err:     ErrInvalidOption,
+		}
+	}
 
 	if selectedOptionNum < 1 {
-		return "", fmt.Errorf("invalid option: You entered a number less than 1")
+		return 0, &ValidationError{
+			Input:   userInputStr,
+			Message: c.message(MsgOptionLessThanOne),
+			Err:     ErrOutOfRange,
+		}
 	}
-	if selectedOptionNum > int64(len(options)) {
-		return "", fmt.Errorf("invalid option: You entered a number greater than the last option's number")
+	if selectedOptionNum > int64(count) {
+		return 0, &ValidationError{
+			Input:   userInputStr,
+			Message: c.message(MsgOptionGreaterThanLast),
+			Err:     ErrOutOfRange,
+		}
 	}
-	return options[selectedOptionNum-1], nil
+	return int(selectedOptionNum), nil
 }
 
-// SelectFromStringsWithDefault ...
-func SelectFromStringsWithDefault(messageToPrint string, defaultValue int, options []string) (string, error) {
-	return SelectFromStringsFromReaderWithDefault(messageToPrint, defaultValue, options, os.Stdin)
+// SelectFromStringsFromReaderWithDefault ...
+//
+// Deprecated: use SelectFromStrings with WithReader and WithDefault.
+func SelectFromStringsFromReaderWithDefault(messageToPrint string, defaultValue int, options []string, inputReader io.Reader) (string, error) {
+	return SelectFromStrings(messageToPrint, options, WithReader(inputReader), WithDefault(defaultValue))
+}
+
+// SelectFromStringsFromReader ...
+//
+// Deprecated: use SelectFromStrings with WithReader.
+func SelectFromStringsFromReader(messageToPrint string, options []string, inputReader io.Reader) (string, error) {
+	return SelectFromStrings(messageToPrint, options, WithReader(inputReader))
 }
 
-// SelectFromStrings ...
-func SelectFromStrings(messageToPrint string, options []string) (string, error) {
-	return SelectFromStringsFromReader(messageToPrint, options, os.Stdin)
+// SelectFromStringsWithDefault ...
+//
+// Deprecated: use SelectFromStrings with WithDefault.
+func SelectFromStringsWithDefault(messageToPrint string, defaultValue int, options []string) (string, error) {
+	return SelectFromStrings(messageToPrint, options, WithDefault(defaultValue))
 }
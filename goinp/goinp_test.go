@@ -2,6 +2,7 @@ package goinp
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"syscall"
 	"testing"
@@ -428,6 +429,50 @@ func TestSelectFromStringsFromReader(t *testing.T) {
 	}
 }
 
+func TestSelectOrCreate(t *testing.T) {
+	availableOptions := []string{"App", "Tests"}
+
+	t.Log("Listed option")
+	{
+		var out bytes.Buffer
+		res, custom, err := SelectOrCreate("Scheme", availableOptions, WithReader(strings.NewReader("2\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, "Tests", res)
+		require.False(t, custom)
+		require.Contains(t, out.String(), "[3] : Other (enter manually)\n")
+	}
+
+	t.Log("Entered manually")
+	{
+		var out bytes.Buffer
+		res, custom, err := SelectOrCreate("Scheme", availableOptions, WithReader(strings.NewReader("3\nApp Store\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, "App Store", res)
+		require.True(t, custom)
+		require.Contains(t, out.String(), "Scheme : ")
+	}
+
+	t.Log("Entered manually, but listed")
+	{
+		res, custom, err := SelectOrCreate("Scheme", availableOptions, WithReader(strings.NewReader("3\nApp\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, "App", res)
+		require.False(t, custom)
+	}
+
+	t.Log("Validator runs on the entered value")
+	{
+		noSpaces := func(answer string) error {
+			if strings.Contains(answer, " ") {
+				return errors.New("should not contain spaces")
+			}
+			return nil
+		}
+		_, _, err := SelectOrCreate("Scheme", availableOptions, WithReader(strings.NewReader("3\nApp Store\n")), WithWriter(&bytes.Buffer{}), WithValidator(noSpaces))
+		require.EqualError(t, err, "should not contain spaces")
+	}
+}
+
 type testStdin struct {
 	Stdin *bytes.Buffer
 }
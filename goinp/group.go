@@ -0,0 +1,165 @@
+package goinp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//=======================================
+// Group
+//=======================================
+
+// Group is a set of questions asked repeatedly by AskForGroup, each round fills an entry.
+type Group[T any] struct {
+	// Ask asks the questions of an entry and fills it, for example with AskForString.
+	// The questions should read from the same reader as the group, see WithReader.
+	Ask func(entry *T) error
+	// Summary renders an entry in the review list, fmt's %v format by default.
+	Summary func(entry T) string
+}
+
+// AskForGroup asks the group's questions repeatedly, until the user answers no to "Add another?".
+// The number of entries can be limited with WithMinItems and WithMaxItems: "Add another?" isn't asked
+// until the minimum is reached and the group ends once the maximum is reached.
+// Without WithMinItems at least one entry is required, unless the group is Optional (then "Add an entry?" is asked first).
+// With WithReview, the entries are listed at the end and the user can delete some of them.
+func AskForGroup[T any](messageToPrint string, group Group[T], opts ...Option) ([]T, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForGroup", listOptions, boolOptions, []string{"Optional", "WithReview", "WithSummaryFormatter"}); err != nil {
+		return nil, err
+	}
+	// the follow-up questions share the buffered input
+	c.input()
+
+	minItems := c.minItems
+	if minItems == 0 && !c.optional {
+		minItems = 1
+	}
+
+	var entries []T
+	for {
+		for c.maxItems == 0 || len(entries) < c.maxItems {
+			if len(entries) >= minItems {
+				another, err := askForAnother(c, len(entries))
+				if err != nil {
+					return nil, err
+				}
+				if !another {
+					break
+				}
+			}
+
+			c.println(c.currentTheme().Prompt.render(c.message(MsgGroupEntry, messageToPrint, len(entries)+1)))
+
+			var entry T
+			if err := group.Ask(&entry); err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+
+		if !c.review {
+			return entries, nil
+		}
+
+		var err error
+		if entries, err = reviewEntries(c, messageToPrint, group, entries, minItems); err != nil {
+			return nil, err
+		}
+		if len(entries) >= minItems {
+			return entries, nil
+		}
+	}
+}
+
+// askForAnother asks whether to add another entry, or the first one if there is none yet.
+func askForAnother(c *config, count int) (bool, error) {
+	question := c.message(MsgGroupAddAnother)
+	if count == 0 {
+		question = c.message(MsgGroupAddFirst)
+	}
+
+	anotherConfig := *c
+	anotherConfig.defaultValue = false
+	anotherConfig.hasDefault = true
+	anotherConfig.validators = nil
+	anotherConfig.optional = false
+	return askForBool(&anotherConfig, question)
+}
+
+// reviewEntries lists the entries and deletes the ones selected by the user, until an empty answer.
+// If an entry is deleted from a full group or the group drops below the minimum, the remaining entries
+// are returned right away, to ask for more.
+func reviewEntries[T any](c *config, messageToPrint string, group Group[T], entries []T, minItems int) ([]T, error) {
+	deleteConfig := *c
+	deleteConfig.hasDefault = false
+	deleteConfig.optional = true
+	deleteConfig.validators = nil
+
+	for len(entries) > 0 {
+		summaries := make([]string, len(entries))
+		for idx, entry := range entries {
+			summaries[idx] = entrySummary(group, entry)
+		}
+
+		idx, err := askForDelete(&deleteConfig, messageToPrint, summaries)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			break
+		}
+
+		full := c.maxItems > 0 && len(entries) == c.maxItems
+		entries = append(entries[:idx], entries[idx+1:]...)
+		if full || len(entries) < minItems {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// askForDelete asks for the number of the entry to delete, -1 is returned for an empty answer.
+func askForDelete(c *config, messageToPrint string, summaries []string) (idx int, err error) {
+	question := c.message(MsgGroupDelete)
+
+	var answer string
+	defer func() { c.finishQuestion(question, answer, err) }()
+
+	theme := c.currentTheme()
+	header := []string{theme.Prompt.render(c.message(MsgGroupEntries, messageToPrint))}
+	for idx, summary := range summaries {
+		header = append(header, fmt.Sprintf("[%d] : %s", idx+1, summary))
+	}
+
+	p := linePrompt{
+		header: header,
+		prompt: theme.Prompt.render(question) + " " + theme.Help.render(c.message(MsgListFinishHint)),
+		filter: IntegerChars,
+		check: func(answer string) error {
+			_, err := selectOption(c, summaries, answer)
+			return err
+		},
+	}
+
+	if answer, err = c.askLine(p); err == ErrEOF {
+		return -1, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if answer == "" {
+		return -1, nil
+	}
+	num, err := strconv.Atoi(answer)
+	if err != nil {
+		return 0, err
+	}
+	return num - 1, nil
+}
+
+func entrySummary[T any](group Group[T], entry T) string {
+	if group.Summary != nil {
+		return group.Summary(entry)
+	}
+	return fmt.Sprintf("%v", entry)
+}
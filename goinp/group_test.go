@@ -0,0 +1,92 @@
+package goinp
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type signingConfig struct {
+	Path  string
+	Alias string
+}
+
+func signingGroup(reader *bufio.Reader) Group[signingConfig] {
+	return Group[signingConfig]{
+		Ask: func(entry *signingConfig) (err error) {
+			if entry.Path, err = AskForPath("Keystore path", WithReader(reader), WithWriter(&bytes.Buffer{})); err != nil {
+				return err
+			}
+			entry.Alias, err = AskForString("Alias", WithReader(reader), WithWriter(&bytes.Buffer{}))
+			return err
+		},
+		Summary: func(entry signingConfig) string {
+			return entry.Path + " (" + entry.Alias + ")"
+		},
+	}
+}
+
+func TestAskForGroup(t *testing.T) {
+	t.Log("Entries until no more")
+	{
+		reader := bufio.NewReader(strings.NewReader("release.jks\nrelease\ny\ndebug.jks\ndebug\nn\n"))
+		var out bytes.Buffer
+		res, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, []signingConfig{{"release.jks", "release"}, {"debug.jks", "debug"}}, res)
+		require.Equal(t, "Signing configuration #1\nAdd another? [yes/NO]: \nSigning configuration #2\nAdd another? [yes/NO]: \n", out.String())
+	}
+
+	t.Log("Min and max")
+	{
+		reader := bufio.NewReader(strings.NewReader("a.jks\na\nb.jks\nb\n"))
+		res, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&bytes.Buffer{}), WithMinItems(2), WithMaxItems(2))
+		require.NoError(t, err)
+		require.Equal(t, []signingConfig{{"a.jks", "a"}, {"b.jks", "b"}}, res)
+	}
+
+	t.Log("Review and delete")
+	{
+		reader := bufio.NewReader(strings.NewReader("a.jks\na\ny\nb.jks\nb\nn\n1\n\n"))
+		var out bytes.Buffer
+		res, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&out), WithReview())
+		require.NoError(t, err)
+		require.Equal(t, []signingConfig{{"b.jks", "b"}}, res)
+		require.Contains(t, out.String(), "Signing configuration:\n[1] : a.jks (a)\n[2] : b.jks (b)\nNumber of the entry to delete (empty answer to finish) : \n")
+		require.Contains(t, out.String(), "Signing configuration:\n[1] : b.jks (b)\n")
+	}
+
+	t.Log("Deleting below the minimum asks for more")
+	{
+		reader := bufio.NewReader(strings.NewReader("a.jks\na\nn\n1\nb.jks\nb\nn\n\n"))
+		res, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&bytes.Buffer{}), WithReview())
+		require.NoError(t, err)
+		require.Equal(t, []signingConfig{{"b.jks", "b"}}, res)
+	}
+
+	t.Log("Optional group, it can end empty")
+	{
+		reader := bufio.NewReader(strings.NewReader("n\n"))
+		var out bytes.Buffer
+		res, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&out), Optional())
+		require.NoError(t, err)
+		require.Empty(t, res)
+		require.Equal(t, "Add an entry? [yes/NO]: \n", out.String())
+
+		// every entry deleted in the review
+		reader = bufio.NewReader(strings.NewReader("y\na.jks\na\nn\n1\n"))
+		res, err = AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&bytes.Buffer{}), Optional(), WithMinItems(0), WithReview())
+		require.NoError(t, err)
+		require.Empty(t, res)
+	}
+
+	t.Log("Invalid entry number")
+	{
+		reader := bufio.NewReader(strings.NewReader("a.jks\na\nn\n3\n"))
+		_, err := AskForGroup("Signing configuration", signingGroup(reader), WithReader(reader), WithWriter(&bytes.Buffer{}), WithReview())
+		require.EqualError(t, err, "invalid option: You entered a number greater than the last option's number")
+	}
+}
@@ -0,0 +1,226 @@
+package goinp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//=======================================
+// Keypress
+//=======================================
+
+// askKeypress asks the question in raw mode and returns the first key pressed as the answer, without waiting for Enter.
+// Keys failing the checks (see checkAnswer) are rejected, Enter is an empty answer. "?" toggles the help if the question has help.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) askKeypress(p linePrompt) (string, error) {
+	c.asked = true
+
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	if c.screen == nil {
+		c.screen = &screen{c: c}
+	}
+
+	prompt := c.renderPrompt(p)
+	answer := ""
+	showHelp := false
+	var keyErr error
+	render := func() {
+		lines := append(append([]string{}, p.header...), prompt+answer)
+		promptLine := len(p.header)
+		if keyErr != nil {
+			lines = append(lines, c.currentTheme().Error.render(keyErr.Error()))
+		}
+		if showHelp {
+			lines = append(lines, c.helpLines()...)
+		}
+		c.screen.render(lines, promptLine, displayWidth(lines[promptLine]))
+	}
+
+	input := c.input()
+	for {
+		render()
+
+		k, err := readKey(input)
+		if err == ErrEOF || k.code == keyEOF {
+			if !c.hasDefault {
+				return "", ErrEOF
+			}
+			k.code = keyEnter
+		} else if err != nil {
+			return "", err
+		}
+
+		switch k.code {
+		case keyInterrupt:
+			return "", ErrInterrupted
+		case keyEnter:
+			if keyErr = c.checkAnswer(p, ""); keyErr == nil {
+				showHelp = false
+				render()
+				return "", nil
+			}
+		case keyRune:
+			if k.r == '?' && c.hasHelp() {
+				showHelp = !showHelp
+				continue
+			}
+			if keyErr = c.checkAnswer(p, string(k.r)); keyErr == nil {
+				answer = string(k.r)
+				showHelp = false
+				render()
+				return answer, nil
+			}
+		}
+	}
+}
+
+//=======================================
+// Choice
+//=======================================
+
+// Choice is an answer of AskForChoice, selected by pressing its key.
+type Choice struct {
+	Key rune
+	// Help describes the choice in the legend, printed when the user answers "?".
+	Help string
+}
+
+// AskForChoice asks the user to press the key of one of the choices, like "Stage this hunk [y,n,q,a,d,?]".
+// In TTY mode the choice is selected by a single key press, in line mode the key is typed in followed by Enter.
+// "?" prints the legend of the choices, then the question is asked again.
+// The keys are matched case sensitively, unless only one key matches ignoring the case.
+// The default value is the key of a choice (rune or string), shown in upper case if that's not the key of another choice.
+// The validators get the key.
+func AskForChoice(messageToPrint string, choices []Choice, opts ...Option) (rune, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForChoice", questionOptions); err != nil {
+		return 0, err
+	}
+	return askForChoice(c, messageToPrint, choices)
+}
+
+func askForChoice(c *config, messageToPrint string, choices []Choice) (key rune, err error) {
+	// the legend is printed as the help of the question
+	var legend []string
+	for _, choice := range choices {
+		legend = append(legend, c.message(MsgChoiceLegend, string(choice.Key), choice.Help))
+	}
+	legend = append(legend, c.message(MsgChoiceLegend, "?", c.message(MsgChoiceHelp)))
+	if help := strings.TrimSpace(c.help); help != "" {
+		legend = append(legend, help)
+	}
+	choiceConfig := *c
+	c = &choiceConfig
+	c.help = strings.Join(legend, "\n")
+
+	defer func() {
+		answer := ""
+		if key != 0 {
+			answer = string(key)
+		}
+		c.finishQuestion(messageToPrint, answer, err)
+	}()
+
+	var defaultKey rune
+	if c.hasDefault {
+		if defaultKey, err = c.defaultKey(choices); err != nil {
+			return 0, err
+		}
+	}
+
+	p := linePrompt{
+		prompt:  c.currentTheme().Prompt.render(messageToPrint),
+		hint:    choiceHint(choices, defaultKey),
+		helpKey: true,
+		check: func(answer string) error {
+			key, err := c.choiceKey(choices, answer)
+			if err != nil {
+				return err
+			}
+			return c.validate(string(key))
+		},
+	}
+
+	var answer string
+	if c.console != nil {
+		answer, err = c.askKeypress(p)
+	} else {
+		answer, err = c.askLine(p)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if answer == "" {
+		return defaultKey, nil
+	}
+	return c.choiceKey(choices, answer)
+}
+
+// choiceKey returns the key of the choice matching the answer.
+func (c *config) choiceKey(choices []Choice, answer string) (rune, error) {
+	if runes := []rune(answer); len(runes) == 1 {
+		var matches []rune
+		for _, choice := range choices {
+			if choice.Key == runes[0] {
+				return choice.Key, nil
+			}
+			if unicode.ToLower(choice.Key) == unicode.ToLower(runes[0]) {
+				matches = append(matches, choice.Key)
+			}
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+	}
+
+	var keys []string
+	for _, choice := range choices {
+		keys = append(keys, string(choice.Key))
+	}
+	return 0, &ValidationError{Input: answer, Message: c.message(MsgChoiceInvalid, answer, strings.Join(keys, ", ")), Err: ErrInvalidOption}
+}
+
+// choiceHint returns the keys of the choices and "?", the default key in upper case if that's not the key of another choice.
+func choiceHint(choices []Choice, defaultKey rune) string {
+	var keys []string
+	for _, choice := range choices {
+		key := choice.Key
+		if key == defaultKey && !hasChoiceKey(choices, unicode.ToUpper(key)) {
+			key = unicode.ToUpper(key)
+		}
+		keys = append(keys, string(key))
+	}
+	return strings.Join(append(keys, "?"), ",")
+}
+
+func hasChoiceKey(choices []Choice, key rune) bool {
+	for _, choice := range choices {
+		if choice.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultKey returns the default value of a choice question.
+func (c *config) defaultKey(choices []Choice) (rune, error) {
+	var key rune
+	switch value := c.defaultValue.(type) {
+	case rune:
+		key = value
+	case string:
+		if runes := []rune(value); len(runes) == 1 {
+			key = runes[0]
+		}
+	}
+	if !hasChoiceKey(choices, key) {
+		return 0, fmt.Errorf("invalid default value (%v) for a choice question, should be the key of a choice", c.defaultValue)
+	}
+	return key, nil
+}
@@ -0,0 +1,102 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testChoices() []Choice {
+	return []Choice{
+		{Key: 'y', Help: "stage this hunk"},
+		{Key: 'n', Help: "do not stage this hunk"},
+		{Key: 'q', Help: "quit"},
+		{Key: 'j', Help: "leave this hunk undecided, see next undecided hunk"},
+		{Key: 'J', Help: "leave this hunk undecided, see next hunk"},
+	}
+}
+
+func TestAskForChoice(t *testing.T) {
+	t.Log("Line mode - ? prints the legend")
+	{
+		var out bytes.Buffer
+		res, err := AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("?\nn\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, 'n', res)
+		require.Equal(t, `Stage this hunk [y,n,q,j,J,?] : 
+y - stage this hunk
+n - do not stage this hunk
+q - quit
+j - leave this hunk undecided, see next undecided hunk
+J - leave this hunk undecided, see next hunk
+? - print help
+Stage this hunk [y,n,q,j,J,?] : 
+`, out.String())
+	}
+
+	t.Log("Keys are case sensitive, unless unambiguous")
+	{
+		res, err := AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("J\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, 'J', res)
+
+		res, err = AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("Q\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, 'q', res)
+
+		_, err = AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("yes\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid option: yes, accepted keys: y, n, q, j, J")
+	}
+
+	t.Log("Default")
+	{
+		var out bytes.Buffer
+		res, err := AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("\n")), WithWriter(&out), WithDefault('n'))
+		require.NoError(t, err)
+		require.Equal(t, 'n', res)
+		require.Equal(t, "Stage this hunk [y,N,q,j,J,?] : \n", out.String())
+
+		_, err = AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+	}
+}
+
+func TestKeypress(t *testing.T) {
+	t.Log("Choice - a single key press")
+	{
+		var out bytes.Buffer
+		// x is not a choice, ? shows the legend
+		res, err := AskForChoice("Stage this hunk", testChoices(), WithReader(strings.NewReader("x?q")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, 'q', res)
+		require.Contains(t, out.String(), "Stage this hunk [y,n,q,j,J,?] : \r\ninvalid option: x, accepted keys: y, n, q, j, J\r\ny - stage this hunk\r\n")
+		require.Equal(t, "✓ Stage this hunk: q\n", lastFrame(out.String()))
+	}
+
+	t.Log("Bool")
+	{
+		var out bytes.Buffer
+		res, err := AskForBool("Continue?", Keypress(), WithReader(strings.NewReader("y")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.True(t, res)
+		require.Equal(t, "✓ Continue? yes\n", lastFrame(out.String()))
+
+		res, err = AskForBool("Continue?", Keypress(), WithReader(strings.NewReader("\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithDefault(true))
+		require.NoError(t, err)
+		require.True(t, res)
+
+		_, err = AskForBool("Continue?", Keypress(), WithReader(strings.NewReader("\x03")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.Equal(t, ErrInterrupted, err)
+	}
+
+	t.Log("Bool in line mode")
+	{
+		res, err := AskForBool("Continue?", Keypress(), WithReader(strings.NewReader("no\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.False(t, res)
+	}
+}
@@ -0,0 +1,199 @@
+package goinp
+
+import (
+	"strings"
+)
+
+//=======================================
+// String list
+//=======================================
+
+// AskForStringList asks for a list of strings, item by item until an empty answer, or in a single line (see SingleLine).
+// The validators run on every item. The number of items can be limited with WithMinItems and WithMaxItems,
+// the repeated items are dropped with Deduplicate. The default value ([]string) is returned if the first answer is empty.
+func AskForStringList(messageToPrint string, opts ...Option) ([]string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForStringList", questionOptions, textOptions, listOptions, []string{"Deduplicate", "SingleLine"}); err != nil {
+		return nil, err
+	}
+	return askForStringList(c, messageToPrint)
+}
+
+func askForStringList(c *config, messageToPrint string) (items []string, err error) {
+	defer func() { c.finishQuestion(messageToPrint, strings.Join(items, ", "), err) }()
+
+	var defaultItems []string
+	if c.hasDefault {
+		if defaultItems, err = c.defaultStrings(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.singleLine {
+		return c.askListLine(messageToPrint, defaultItems)
+	}
+	return c.askListItems(messageToPrint, defaultItems)
+}
+
+// askListItems asks for the items one by one. In TTY mode the answered items are redrawn above the current one.
+func (c *config) askListItems(messageToPrint string, defaultItems []string) ([]string, error) {
+	theme := c.currentTheme()
+
+	question := theme.Prompt.render(messageToPrint)
+	if len(defaultItems) > 0 {
+		question += " " + theme.DefaultHint.render("["+strings.Join(defaultItems, ", ")+"]")
+	}
+	question += " " + theme.Help.render(c.message(MsgListFinishHint))
+
+	minItems := c.minItems
+	if minItems == 0 && !c.hasDefault && !c.optional {
+		minItems = 1
+	}
+
+	var items []string
+	var answered []string
+	for c.maxItems == 0 || len(items) < c.maxItems {
+		p := linePrompt{
+			prompt: theme.Prompt.render(c.message(MsgListItem, len(items)+1)),
+			check:  c.validate,
+		}
+		p.checkEmpty = func() error {
+			if len(items) >= minItems || (len(items) == 0 && c.hasDefault) {
+				return nil
+			}
+			return c.tooFewItems(minItems)
+		}
+		if c.console != nil {
+			p.header = append([]string{question}, answered...)
+		} else if len(items) == 0 {
+			p.header = []string{question}
+		}
+
+		answer, err := c.askLine(p)
+		if err == ErrEOF && p.checkEmpty() == nil {
+			answer, err = "", nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			break
+		}
+
+		if c.deduplicate && containsString(items, answer) {
+			continue
+		}
+		items = append(items, answer)
+		answered = append(answered, c.renderPrompt(p)+answer)
+	}
+
+	if len(items) == 0 && c.hasDefault {
+		return defaultItems, nil
+	}
+	return items, nil
+}
+
+// askListLine asks for the items in a single line.
+func (c *config) askListLine(messageToPrint string, defaultItems []string) ([]string, error) {
+	p := linePrompt{
+		prompt: c.currentTheme().Prompt.render(messageToPrint),
+		hint:   strings.Join(defaultItems, ", "),
+		check: func(answer string) error {
+			_, err := c.parseList(answer)
+			return err
+		},
+	}
+
+	answer, err := c.askLine(p)
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		return defaultItems, nil
+	}
+	return c.parseList(answer)
+}
+
+// parseList splits a single line answer into items, and checks them.
+func (c *config) parseList(answer string) ([]string, error) {
+	items, err := c.splitList(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, item := range items {
+		if c.deduplicate && containsString(result, item) {
+			continue
+		}
+		if err := c.validate(item); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	if len(result) < c.minItems {
+		return nil, &ValidationError{Input: answer, Message: c.message(MsgListTooFew, c.minItems), Err: ErrOutOfRange}
+	}
+	if c.maxItems > 0 && len(result) > c.maxItems {
+		return nil, &ValidationError{Input: answer, Message: c.message(MsgListTooMany, c.maxItems), Err: ErrOutOfRange}
+	}
+	return result, nil
+}
+
+// splitList splits the line at the commas and whitespace, outside of quotes. Empty items are dropped.
+func (c *config) splitList(line string) ([]string, error) {
+	var items []string
+	var item strings.Builder
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			item.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				item.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',' || r == ' ' || r == '\t':
+			if item.Len() > 0 {
+				items = append(items, item.String())
+				item.Reset()
+			}
+		default:
+			item.WriteRune(r)
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, &ValidationError{Input: line, Message: c.message(MsgListUnterminatedQuote), Err: ErrInvalidOption}
+	}
+	if item.Len() > 0 {
+		items = append(items, item.String())
+	}
+	return items, nil
+}
+
+func (c *config) tooFewItems(minItems int) error {
+	if c.minItems == 0 {
+		return &ValidationError{Err: ErrEmptyInput}
+	}
+	return &ValidationError{Message: c.message(MsgListTooFew, minItems), Err: ErrOutOfRange}
+}
+
+func containsString(items []string, item string) bool {
+	for _, existing := range items {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
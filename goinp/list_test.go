@@ -0,0 +1,118 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForStringList(t *testing.T) {
+	t.Log("Items until an empty answer")
+	{
+		var out bytes.Buffer
+		res, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader("--info\n--stacktrace\n\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, []string{"--info", "--stacktrace"}, res)
+		require.Equal(t, "Gradle arguments (empty answer to finish)\nitem 1 : item 2 : item 3 : \n", out.String())
+	}
+
+	t.Log("End of input finishes the list")
+	{
+		res, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader("--info")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"--info"}, res)
+	}
+
+	t.Log("At least one item is required")
+	{
+		_, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+	}
+
+	t.Log("Default")
+	{
+		res, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault([]string{"--info"}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"--info"}, res)
+	}
+
+	t.Log("Min items")
+	{
+		_, err := AskForStringList("Recipients", WithReader(strings.NewReader("a@b.c\n\n")), WithWriter(&bytes.Buffer{}), WithMinItems(2))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+		require.EqualError(t, err, "at least 2 items are required")
+	}
+
+	t.Log("Max items")
+	{
+		res, err := AskForStringList("Recipients", WithReader(strings.NewReader("a\nb\nc\n")), WithWriter(&bytes.Buffer{}), WithMaxItems(2))
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, res)
+	}
+
+	t.Log("Deduplicate")
+	{
+		res, err := AskForStringList("Recipients", WithReader(strings.NewReader("a\nb\na\n\n")), WithWriter(&bytes.Buffer{}), Deduplicate())
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, res)
+	}
+
+	t.Log("Validators run on every item")
+	{
+		email := func(answer string) error {
+			if !strings.Contains(answer, "@") {
+				return fmt.Errorf("not an email: %s", answer)
+			}
+			return nil
+		}
+		_, err := AskForStringList("Recipients", WithReader(strings.NewReader("a@b.c\nabc\n\n")), WithWriter(&bytes.Buffer{}), WithValidator(email))
+		require.EqualError(t, err, "not an email: abc")
+	}
+}
+
+func TestAskForStringListTTY(t *testing.T) {
+	var out bytes.Buffer
+	res, err := AskForStringList("Recipients", WithReader(strings.NewReader("a@b.c\r\r\rd@e.f\r\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithMinItems(2))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a@b.c", "d@e.f"}, res)
+	require.Contains(t, out.String(), "Recipients (empty answer to finish)\r\nitem 1 : a@b.c\r\nitem 2 : \r\nat least 2 items are required")
+	require.Equal(t, "✓ Recipients: a@b.c, d@e.f\n", lastFrame(out.String()))
+}
+
+func TestAskForStringListSingleLine(t *testing.T) {
+	t.Log("Separated by commas and whitespace, with quotes")
+	{
+		res, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader(`--info, -Pname="My App" 'a, b' c\ d`+"\n")), WithWriter(&bytes.Buffer{}), SingleLine())
+		require.NoError(t, err)
+		require.Equal(t, []string{"--info", "-Pname=My App", "a, b", "c d"}, res)
+	}
+
+	t.Log("Unterminated quote")
+	{
+		_, err := AskForStringList("Gradle arguments", WithReader(strings.NewReader(`"--info`+"\n")), WithWriter(&bytes.Buffer{}), SingleLine())
+		require.True(t, errors.Is(err, ErrInvalidOption))
+	}
+
+	t.Log("Default, min and max")
+	{
+		var out bytes.Buffer
+		res, err := AskForStringList("Recipients", WithReader(strings.NewReader("\n")), WithWriter(&out), SingleLine(), WithDefault([]string{"a", "b"}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, res)
+		require.Equal(t, "Recipients [a, b] : \n", out.String())
+
+		_, err = AskForStringList("Recipients", WithReader(strings.NewReader("a b c\n")), WithWriter(&bytes.Buffer{}), SingleLine(), WithMaxItems(2))
+		require.EqualError(t, err, "at most 2 items are allowed")
+	}
+
+	t.Log("Deduplicate")
+	{
+		res, err := AskForStringList("Recipients", WithReader(strings.NewReader("a,b,a\n")), WithWriter(&bytes.Buffer{}), SingleLine(), Deduplicate())
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, res)
+	}
+}
@@ -0,0 +1,330 @@
+package goinp
+
+import (
+	"strconv"
+)
+
+//=======================================
+// Menu
+//=======================================
+
+// menu selects an item of a list in raw mode, with the arrow keys or by typing in the answer.
+// The items are the options of a select question, unless the hooks list other items (see the tree and order menus).
+type menu struct {
+	c      *config
+	screen *screen
+	title  []string
+	// prompt is shown under the items, hint is the default answer.
+	prompt string
+	hint   string
+	check  func(answer string) error
+	// keys handles the keys specific to the question, it returns true if it handled the key.
+	keys func(k key) bool
+
+	// options of a select question and their numbers, see numberOptions.
+	options []SelectOption
+	numbers []int
+
+	// rows returns the rows of the items (the current one rendered as selected), and the indexes of the rows which can be highlighted.
+	rows func(current int) ([]string, []int)
+	// answer returns the answer selecting the current item.
+	answer func(current int) string
+	// find returns the item selected by a valid answer, -1 to keep the current one.
+	find func(answer string) int
+	// accept filters the characters typed in, digits by default.
+	accept func(r rune) bool
+	// marker is shown before the current item, "> " by default.
+	marker string
+
+	// current is the index of the highlighted item, input is the typed in answer.
+	current int
+	input   []rune
+	// top is the first item row shown, if the items don't fit on the screen.
+	top      int
+	showHelp bool
+	done     bool
+	err      error
+}
+
+// selectInMenu asks for the number of the selected option in TTY mode. The default option is highlighted first.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) selectInMenu(messageToPrint string, options []SelectOption, defaultValue int, check func(answer string) error) (int, error) {
+	answer, err := c.runMenu(&menu{
+		title:   optionLines(c, messageToPrint, nil, 0),
+		options: options,
+		numbers: numberOptions(options),
+		check:   check,
+	}, c.defaultAnswer(defaultValue))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+// defaultAnswer returns the number of the default option as the default answer of the menu, empty if there is no default.
+func (c *config) defaultAnswer(defaultValue int) string {
+	if !c.hasDefault {
+		return ""
+	}
+	return strconv.Itoa(defaultValue)
+}
+
+// runMenu runs the menu in raw mode, and returns the answer. The default item (or the first one) is highlighted first.
+func (c *config) runMenu(m *menu, defaultAnswer string) (string, error) {
+	c.asked = true
+
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	if c.screen == nil {
+		c.screen = &screen{c: c}
+	}
+
+	m.c = c
+	m.screen = c.screen
+	if m.prompt == "" {
+		m.prompt = c.message(MsgSelectOptionNumber)
+	}
+	if defaultAnswer != "" {
+		m.hint = defaultAnswer
+		if idx := m.findItem(defaultAnswer); idx >= 0 {
+			m.current = idx
+		}
+	}
+	return m.run()
+}
+
+func (m *menu) run() (string, error) {
+	input := m.c.input()
+	for {
+		m.render()
+
+		k, err := readKey(input)
+		if err != nil {
+			m.finish()
+			return "", err
+		}
+		if m.keys != nil && m.keys(k) {
+			continue
+		}
+
+		switch k.code {
+		case keyInterrupt:
+			m.finish()
+			return "", ErrInterrupted
+		case keyEOF:
+			if len(m.input) == 0 {
+				m.finish()
+				return "", ErrEOF
+			}
+		case keyEnter:
+			answer := m.currentAnswer()
+			if m.err = m.check(answer); m.err != nil {
+				continue
+			}
+			if len(m.input) > 0 {
+				m.follow(answer)
+			}
+			m.input = nil
+			m.finish()
+			return answer, nil
+		case keyUp, keyDown, keyHome, keyEnd, keyPageUp, keyPageDown:
+			m.move(k.code)
+		case keyBackspace:
+			if len(m.input) > 0 {
+				m.input = m.input[:len(m.input)-1]
+			}
+		case keyRune:
+			if k.r == '?' && len(m.input) == 0 && m.c.hasHelp() {
+				m.showHelp = !m.showHelp
+				continue
+			}
+			if !m.accepts(k.r) {
+				continue
+			}
+			m.input = append(m.input, k.r)
+		}
+
+		m.err = nil
+		if len(m.input) > 0 {
+			if m.err = m.check(string(m.input)); m.err == nil {
+				m.follow(string(m.input))
+			}
+		}
+	}
+}
+
+// move moves the highlight in the order the items are listed, wrapping around at the ends of the list for Up and Down.
+func (m *menu) move(code keyCode) {
+	m.input = nil
+
+	_, selectable := m.list()
+	if len(selectable) == 0 {
+		return
+	}
+	idx := minInt(m.current, len(selectable)-1)
+
+	switch code {
+	case keyUp:
+		idx = (idx - 1 + len(selectable)) % len(selectable)
+	case keyDown:
+		idx = (idx + 1) % len(selectable)
+	case keyPageUp:
+		idx = maxInt(idx-m.visibleRows(), 0)
+	case keyPageDown:
+		idx = minInt(idx+m.visibleRows(), len(selectable)-1)
+	case keyHome:
+		idx = 0
+	case keyEnd:
+		idx = len(selectable) - 1
+	}
+	m.current = idx
+}
+
+// follow highlights the item selected by the answer.
+func (m *menu) follow(answer string) {
+	if idx := m.findItem(answer); idx >= 0 {
+		m.current = idx
+	}
+}
+
+func (m *menu) accepts(r rune) bool {
+	if m.accept != nil {
+		return m.accept(r)
+	}
+	return r >= '0' && r <= '9'
+}
+
+func (m *menu) currentAnswer() string {
+	if len(m.input) > 0 {
+		return string(m.input)
+	}
+	if m.answer != nil {
+		return m.answer(m.current)
+	}
+	if order := m.order(); m.current < len(order) {
+		return strconv.Itoa(order[m.current])
+	}
+	return ""
+}
+
+func (m *menu) findItem(answer string) int {
+	if m.find != nil {
+		return m.find(answer)
+	}
+	num, err := strconv.Atoi(answer)
+	if err != nil {
+		return -1
+	}
+	for idx, optionNum := range m.order() {
+		if optionNum == num {
+			return idx
+		}
+	}
+	return -1
+}
+
+// list returns the rows of the items, and the indexes of the rows which can be highlighted.
+func (m *menu) list() ([]string, []int) {
+	if m.rows != nil {
+		return m.rows(m.current)
+	}
+	highlighted := 0
+	if order := m.order(); m.current < len(order) {
+		highlighted = order[m.current]
+	}
+	return optionRows(m.c, m.options, m.numbers, highlighted)
+}
+
+// order returns the numbers of the selectable options, in the order they are listed.
+func (m *menu) order() []int {
+	var order []int
+	for _, num := range m.numbers {
+		if num > 0 {
+			order = append(order, num)
+		}
+	}
+	return order
+}
+
+// visibleRows returns the number of item rows fitting on the screen, below the title and above the prompt.
+func (m *menu) visibleRows() int {
+	_, height := m.c.console.size()
+	if rows := height - len(m.title) - 3; rows > 3 {
+		return rows
+	}
+	return 3
+}
+
+func (m *menu) lines() ([]string, int) {
+	theme := m.c.currentTheme()
+
+	rows, selectable := m.list()
+	current := -1
+	if m.current < len(selectable) {
+		current = selectable[m.current]
+	}
+	marker := m.marker
+	if marker == "" {
+		marker = "> "
+	}
+	for idx := range rows {
+		if idx == current {
+			rows[idx] = theme.Selected.render(marker) + rows[idx]
+		} else {
+			rows[idx] = "  " + rows[idx]
+		}
+	}
+
+	if visible := m.visibleRows(); len(rows) > visible {
+		if current < m.top {
+			m.top = current
+		} else if current >= m.top+visible {
+			m.top = current - visible + 1
+		}
+		if m.top < 0 {
+			m.top = 0
+		}
+		rows = rows[m.top:minInt(m.top+visible, len(rows))]
+	}
+
+	lines := append(append([]string{}, m.title...), rows...)
+	promptLine := len(lines)
+	lines = append(lines, m.c.renderPrompt(linePrompt{prompt: theme.Help.render(m.prompt), hint: m.hint})+string(m.input))
+	if m.err != nil && !m.done {
+		lines = append(lines, theme.Error.render(m.err.Error()))
+	}
+	if m.showHelp {
+		lines = append(lines, m.c.helpLines()...)
+	}
+	return lines, promptLine
+}
+
+func (m *menu) render() {
+	lines, promptLine := m.lines()
+	m.screen.render(lines, promptLine, displayWidth(lines[promptLine]))
+}
+
+// finish draws the final state of the menu, without the help.
+func (m *menu) finish() {
+	m.showHelp = false
+	m.done = true
+	m.render()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
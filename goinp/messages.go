@@ -0,0 +1,259 @@
+package goinp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// MessageID identifies a user-visible text (prompt or error message) of goinp.
+type MessageID string
+
+// Messages used by goinp, the English text is in EnglishCatalog.
+const (
+	MsgSelectFromList        MessageID = "select_from_list"
+	MsgSelectOptionNumber    MessageID = "select_option_number"
+	MsgSelectOther           MessageID = "select_other"
+	MsgSelectPage            MessageID = "select_page"
+	MsgSelectFiltered        MessageID = "select_filtered"
+	MsgSelectNoMatch         MessageID = "select_no_match"
+	MsgTreeSelectPath        MessageID = "tree_select_path"
+	MsgTableSortHint         MessageID = "table_sort_hint"
+	MsgTreeNoMatch           MessageID = "tree_no_match"
+	MsgTreeAmbiguous         MessageID = "tree_ambiguous"
+	MsgTreeNotLeaf           MessageID = "tree_not_leaf"
+	MsgOptionNotANumber      MessageID = "option_not_a_number"
+	MsgOptionLessThanOne     MessageID = "option_less_than_one"
+	MsgOptionGreaterThanLast MessageID = "option_greater_than_last"
+	MsgBoolYesWords          MessageID = "bool_yes_words"
+	MsgBoolNoWords           MessageID = "bool_no_words"
+	MsgBoolInvalid           MessageID = "bool_invalid"
+	MsgConfirmAffected       MessageID = "confirm_affected"
+	MsgConfirmTypePhrase     MessageID = "confirm_type_phrase"
+	MsgConfirmAreYouSure     MessageID = "confirm_are_you_sure"
+	MsgPlaceholderHint       MessageID = "placeholder_hint"
+	MsgHelpHint              MessageID = "help_hint"
+	MsgHelpURL               MessageID = "help_url"
+	MsgSuggestions           MessageID = "suggestions"
+	MsgListItem              MessageID = "list_item"
+	MsgListFinishHint        MessageID = "list_finish_hint"
+	MsgListTooFew            MessageID = "list_too_few"
+	MsgListTooMany           MessageID = "list_too_many"
+	MsgListUnterminatedQuote MessageID = "list_unterminated_quote"
+	MsgEnvVarFinishHint      MessageID = "env_var_finish_hint"
+	MsgEnvVarFormat          MessageID = "env_var_format"
+	MsgEnvVarInvalidKey      MessageID = "env_var_invalid_key"
+	MsgEnvVarDuplicate       MessageID = "env_var_duplicate"
+	MsgEnvVarTrailing        MessageID = "env_var_trailing"
+	MsgEnvVarIsSecret        MessageID = "env_var_is_secret"
+	MsgGroupEntry            MessageID = "group_entry"
+	MsgGroupAddAnother       MessageID = "group_add_another"
+	MsgGroupAddFirst         MessageID = "group_add_first"
+	MsgGroupEntries          MessageID = "group_entries"
+	MsgGroupDelete           MessageID = "group_delete"
+	MsgOrderItems            MessageID = "order_items"
+	MsgOrderNumbers          MessageID = "order_numbers"
+	MsgOrderMoveHint         MessageID = "order_move_hint"
+	MsgOrderRepeated         MessageID = "order_repeated"
+	MsgOrderMissing          MessageID = "order_missing"
+	MsgChoiceLegend          MessageID = "choice_legend"
+	MsgChoiceHelp            MessageID = "choice_help"
+	MsgChoiceInvalid         MessageID = "choice_invalid"
+	MsgPauseAnyKey           MessageID = "pause_any_key"
+	MsgPauseEnter            MessageID = "pause_enter"
+	MsgPauseTimeout          MessageID = "pause_timeout"
+	MsgPagerStatus           MessageID = "pager_status"
+	MsgPagerNotFound         MessageID = "pager_not_found"
+	MsgDiffAccept            MessageID = "diff_accept"
+	MsgDiffReject            MessageID = "diff_reject"
+	MsgDiffEdit              MessageID = "diff_edit"
+	MsgIntInvalid            MessageID = "int_invalid"
+	MsgIntRange              MessageID = "int_range"
+	MsgIntOutOfRange         MessageID = "int_out_of_range"
+	MsgStepperHint           MessageID = "stepper_hint"
+	MsgInvalidInput          MessageID = "invalid_input"
+	MsgInvalidCharacter      MessageID = "invalid_character"
+	MsgReadFailed            MessageID = "read_failed"
+
+	MsgErrInterrupted          MessageID = "err_interrupted"
+	MsgErrEOF                  MessageID = "err_eof"
+	MsgErrEmptyInput           MessageID = "err_empty_input"
+	MsgErrInvalidOption        MessageID = "err_invalid_option"
+	MsgErrOutOfRange           MessageID = "err_out_of_range"
+	MsgErrConfirmationRequired MessageID = "err_confirmation_required"
+)
+
+// Catalog maps the message IDs to their texts in a given language.
+// The texts are fmt format strings, they get the same arguments as the English ones.
+// Messages missing from a catalog fall back to English.
+type Catalog map[MessageID]string
+
+// EnglishCatalog is the default catalog.
+var EnglishCatalog = Catalog{
+	MsgSelectFromList:        "Please select from the list:",
+	MsgSelectOptionNumber:    "(type in the option's number, then hit Enter)",
+	MsgSelectOther:           "Other (enter manually)",
+	MsgSelectPage:            "Page %d/%d (n: next page, p: previous page, /text: filter)",
+	MsgSelectFiltered:        "Options matching \"%s\" (/ to clear the filter)",
+	MsgSelectNoMatch:         "No matching options",
+	MsgTreeSelectPath:        "(type in the option's number or path, then hit Enter)",
+	MsgTableSortHint:         "(Tab: sort by the next column, Shift-Tab: reverse the order)",
+	MsgTreeNoMatch:           "invalid option: no option matches %s",
+	MsgTreeAmbiguous:         "invalid option: %s matches more options, type in its full path",
+	MsgTreeNotLeaf:           "invalid option: %s has sub-options, select one of them",
+	MsgOptionNotANumber:      "invalid option: %s is not a number",
+	MsgOptionLessThanOne:     "invalid option: You entered a number less than 1",
+	MsgOptionGreaterThanLast: "invalid option: You entered a number greater than the last option's number",
+	MsgBoolYesWords:          "yes,y",
+	MsgBoolNoWords:           "no,n",
+	MsgBoolInvalid:           "invalid option: %s, accepted values: %s",
+	MsgConfirmAffected:       "This will affect:",
+	MsgConfirmTypePhrase:     "Type \"%s\" to confirm",
+	MsgConfirmAreYouSure:     "Are you sure?",
+	MsgPlaceholderHint:       "(e.g. %s)",
+	MsgHelpHint:              "(? for help)",
+	MsgHelpURL:               "More information: %s",
+	MsgSuggestions:           "Suggestions: %s",
+	MsgListItem:              "item %d",
+	MsgListFinishHint:        "(empty answer to finish)",
+	MsgListTooFew:            "at least %d items are required",
+	MsgListTooMany:           "at most %d items are allowed",
+	MsgListUnterminatedQuote: "unterminated quote",
+	MsgEnvVarFinishHint:      "(KEY=value, empty answer to finish)",
+	MsgEnvVarFormat:          "invalid variable: %s, should be KEY=value",
+	MsgEnvVarInvalidKey:      "invalid variable name: %s, should contain letters, digits and _, and not start with a digit",
+	MsgEnvVarDuplicate:       "duplicate variable: %s",
+	MsgEnvVarTrailing:        "unexpected text after the quoted value: %s",
+	MsgEnvVarIsSecret:        "Is %s a secret?",
+	MsgGroupEntry:            "%s #%d",
+	MsgGroupAddAnother:       "Add another?",
+	MsgGroupAddFirst:         "Add an entry?",
+	MsgGroupEntries:          "%s:",
+	MsgGroupDelete:           "Number of the entry to delete",
+	MsgOrderItems:            "Put the items in order:",
+	MsgOrderNumbers:          "(type in the numbers in the new order, like 3,1,2, then hit Enter)",
+	MsgOrderMoveHint:         "(Space: pick up / drop the item, Up / Down: move it)",
+	MsgOrderRepeated:         "invalid order: %d is listed more than once",
+	MsgOrderMissing:          "invalid order: missing %s",
+	MsgChoiceLegend:          "%s - %s",
+	MsgChoiceHelp:            "print help",
+	MsgChoiceInvalid:         "invalid option: %s, accepted keys: %s",
+	MsgPauseAnyKey:           "(press any key to continue)",
+	MsgPauseEnter:            "(press Enter to continue)",
+	MsgPauseTimeout:          "(continuing in %s)",
+	MsgPagerStatus:           "lines %d-%d of %d (Space: next page, b: previous page, /: search, q: quit)",
+	MsgPagerNotFound:         "not found: %s",
+	MsgDiffAccept:            "accept the changes",
+	MsgDiffReject:            "reject the changes",
+	MsgDiffEdit:              "edit the new content",
+	MsgIntInvalid:            "invalid number: %s",
+	MsgIntRange:              "(%d-%d)",
+	MsgIntOutOfRange:         "value out of range: should be between %d and %d",
+	MsgStepperHint:           "(Up / Down: change the value, Page Up / Page Down: change it by %d, or type it in)",
+	MsgInvalidInput:          "invalid input: %s",
+	MsgInvalidCharacter:      "invalid character: %q",
+	MsgReadFailed:            "failed to get input - read failed with error: %s",
+
+	MsgErrInterrupted:          "interrupted",
+	MsgErrEOF:                  "failed to get input - end of input",
+	MsgErrEmptyInput:           "value must be specified",
+	MsgErrInvalidOption:        "invalid option",
+	MsgErrOutOfRange:           "value out of range",
+	MsgErrConfirmationRequired: "confirmation required, but the input is not interactive",
+}
+
+const defaultLocale = "en"
+
+// catalogsMutex guards catalogs and locale, they can be set while questions are asked.
+var catalogsMutex sync.RWMutex
+
+var catalogs = map[string]Catalog{defaultLocale: EnglishCatalog}
+
+// locale set by SetLocale, if empty the locale is detected from the environment.
+var locale string
+
+// RegisterCatalog registers the translations for the given locale, like "de" or "pt_BR".
+func RegisterCatalog(locale string, catalog Catalog) {
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	catalogs[normalizeLocale(locale)] = catalog
+}
+
+// SetLocale sets the locale of the messages. If empty, the locale is detected from the environment (see DetectLocale).
+func SetLocale(newLocale string) {
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	locale = normalizeLocale(newLocale)
+}
+
+// currentLocale returns the locale set by SetLocale.
+func currentLocale() string {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	return locale
+}
+
+// DetectLocale returns the locale set by the LC_ALL, LC_MESSAGES or LANG environment variables, "en" if none of them is set.
+func DetectLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := normalizeLocale(os.Getenv(key)); value != "" {
+			return value
+		}
+	}
+	return defaultLocale
+}
+
+// normalizeLocale strips the encoding and modifier from a POSIX locale ("de_DE.UTF-8@euro" -> "de_DE").
+func normalizeLocale(value string) string {
+	if idx := strings.IndexAny(value, ".@"); idx != -1 {
+		value = value[:idx]
+	}
+	value = strings.Replace(value, "-", "_", -1)
+	if value == "C" || value == "POSIX" {
+		return defaultLocale
+	}
+	return value
+}
+
+// message returns the text of the message in the current locale, formatted with the given arguments.
+// Lookup order for "de_DE": "de_DE", "de", English.
+func message(id MessageID, args ...interface{}) string {
+	return messageInLocale(currentLocale(), id, args...)
+}
+
+func messageInLocale(messageLocale string, id MessageID, args ...interface{}) string {
+	if messageLocale == "" {
+		messageLocale = DetectLocale()
+	}
+
+	candidates := []string{messageLocale}
+	if idx := strings.Index(messageLocale, "_"); idx != -1 {
+		candidates = append(candidates, messageLocale[:idx])
+	}
+	candidates = append(candidates, defaultLocale)
+
+	text := string(id)
+	catalogsMutex.RLock()
+	for _, candidate := range candidates {
+		if translated, ok := catalogs[candidate][id]; ok {
+			text = translated
+			break
+		}
+	}
+	catalogsMutex.RUnlock()
+
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// messageError is an error with a localised message, the sentinel errors are messageErrors.
+type messageError struct {
+	id MessageID
+}
+
+func (e *messageError) Error() string {
+	return message(e.id)
+}
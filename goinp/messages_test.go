@@ -0,0 +1,105 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	require.Equal(t, "de_DE", normalizeLocale("de_DE.UTF-8"))
+	require.Equal(t, "de_DE", normalizeLocale("de_DE@euro"))
+	require.Equal(t, "pt_BR", normalizeLocale("pt-BR"))
+	require.Equal(t, "en", normalizeLocale("C"))
+	require.Equal(t, "", normalizeLocale(""))
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+	require.Equal(t, "en", DetectLocale())
+
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	require.Equal(t, "fr_FR", DetectLocale())
+
+	t.Setenv("LC_ALL", "de_AT.UTF-8")
+	require.Equal(t, "de_AT", DetectLocale())
+}
+
+func TestMessages(t *testing.T) {
+	RegisterCatalog("de", Catalog{
+		MsgOptionNotANumber: "ungültige Option: %s ist keine Zahl",
+		MsgBoolYesWords:     "ja,j",
+		MsgBoolNoWords:      "nein,n",
+		MsgErrEmptyInput:    "Wert muss angegeben werden",
+	})
+	defer delete(catalogs, "de")
+	defer SetLocale("")
+
+	t.Log("Explicit locale, falls back to the language")
+	{
+		SetLocale("de_AT.UTF-8")
+		require.Equal(t, "ungültige Option: abc ist keine Zahl", message(MsgOptionNotANumber, "abc"))
+	}
+
+	t.Log("Missing translation falls back to English")
+	{
+		require.Equal(t, "Please select from the list:", message(MsgSelectFromList))
+	}
+
+	t.Log("Localised errors")
+	{
+		_, err := AskForStringFromReader("Enter some text", strings.NewReader("\n"))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+		require.EqualError(t, err, "Wert muss angegeben werden")
+
+		_, err = SelectFromStringsFromReader("Select something", []string{"first"}, strings.NewReader("abc"))
+		require.EqualError(t, err, "ungültige Option: abc ist keine Zahl")
+	}
+
+	t.Log("Localised bool words")
+	{
+		value, err := ParseBool("ja")
+		require.NoError(t, err)
+		require.True(t, value)
+		require.Equal(t, "JA/nein", currentBoolVocabulary().hint(true, true))
+
+		// the English words are still accepted
+		value, err = ParseBool("yes")
+		require.NoError(t, err)
+		require.True(t, value)
+		res, err := AskForBool("Deploy?", WithReader(strings.NewReader("no\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.False(t, res)
+	}
+
+	t.Log("Catalogs registered while asking")
+	{
+		var wg sync.WaitGroup
+		for idx := 0; idx < 10; idx++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				RegisterCatalog("fr", Catalog{MsgErrEmptyInput: "valeur requise"})
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = AskForString("Name", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithLocale("fr"))
+			}()
+		}
+		wg.Wait()
+		delete(catalogs, "fr")
+	}
+
+	t.Log("Locale from the environment")
+	{
+		SetLocale("")
+		t.Setenv("LC_ALL", "de_DE.UTF-8")
+		require.Equal(t, "ungültige Option: abc ist keine Zahl", message(MsgOptionNotANumber, "abc"))
+	}
+}
@@ -0,0 +1,524 @@
+package goinp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+// Option configures a question.
+type Option func(*config)
+
+// Validator checks the answer before it's returned, the answer is rejected if it returns an error.
+// It gets the answer as text (the selected option for select questions).
+type Validator func(answer string) error
+
+type config struct {
+	reader io.Reader
+	writer io.Writer
+	// in buffers the reader, see input.
+	in *bufio.Reader
+
+	defaultValue  interface{}
+	hasDefault    bool
+	optional      bool
+	validators    []Validator
+	help          string
+	helpURL       string
+	placeholder   string
+	secret        bool
+	charFilter    CharFilter
+	completer     Completer
+	keypress      bool
+	timeout       time.Duration
+	abortKeys     []rune
+	externalPager bool
+	sideBySide    bool
+	fileName      string
+	step          int64
+
+	// list questions
+	minItems    int
+	maxItems    int
+	deduplicate bool
+	singleLine  bool
+	secretFlag  bool
+	review      bool
+	pageSize    int
+	anyNode     bool
+
+	summaryFormatter SummaryFormatter
+
+	theme      *Theme
+	vocabulary *BoolVocabulary
+	locale     string
+
+	// used lists the options set (except the ones every question supports), see checkOptions.
+	used []string
+
+	// console is set in TTY mode, nil in line mode.
+	console console
+	// screen of the question being asked in TTY mode.
+	screen *screen
+	// asked is set when the question got printed, until finishQuestion.
+	asked bool
+}
+
+// WithDefault sets the default value, returned if the answer is empty.
+// Its type has to match the question: string for string and path questions, any integer type for int questions, bool for bool questions,
+// and the option (string) or the option's number (int, starting from 1) for select questions.
+func WithDefault(value interface{}) Option {
+	return func(c *config) {
+		c.use("WithDefault")
+		c.defaultValue = value
+		c.hasDefault = true
+	}
+}
+
+// WithReader sets the reader the answer is read from, os.Stdin by default.
+func WithReader(reader io.Reader) Option {
+	return func(c *config) {
+		c.reader = reader
+	}
+}
+
+// WithWriter sets the writer the question is printed to, os.Stdout by default.
+func WithWriter(writer io.Writer) Option {
+	return func(c *config) {
+		c.writer = writer
+	}
+}
+
+// WithValidator adds a validator, the answer is rejected with a *ValidationError wrapping the validator's error if it fails.
+func WithValidator(validator Validator) Option {
+	return func(c *config) {
+		c.use("WithValidator")
+		c.validators = append(c.validators, validator)
+	}
+}
+
+// WithHelp sets the help text of the question, shown when the user answers "?" (presses "?" in TTY mode),
+// then the question is asked again.
+func WithHelp(help string) Option {
+	return func(c *config) {
+		c.use("WithHelp")
+		c.help = help
+	}
+}
+
+// WithHelpURL sets the URL of the question's documentation, shown together with the help text.
+func WithHelpURL(url string) Option {
+	return func(c *config) {
+		c.use("WithHelpURL")
+		c.helpURL = url
+	}
+}
+
+// WithCharFilter sets which characters are accepted in the answer.
+// In TTY mode other keystrokes are ignored, in line mode an answer with other characters is rejected.
+func WithCharFilter(filter CharFilter) Option {
+	return func(c *config) {
+		c.use("WithCharFilter")
+		c.charFilter = filter
+	}
+}
+
+// WithSuggestions offers the given completions, the ones starting with the answer typed in so far.
+// In TTY mode they are shown under the input, Tab or Right accepts the selected one (Up/Down selects).
+// In line mode they are listed before the prompt and an answer ending with Tab is expanded if it's the prefix of a single suggestion.
+func WithSuggestions(suggestions ...string) Option {
+	return func(c *config) {
+		c.use("WithSuggestions")
+		c.completer = prefixCompleter(suggestions)
+	}
+}
+
+// WithCompleter offers the completions returned by the completer, like WithSuggestions.
+func WithCompleter(completer Completer) Option {
+	return func(c *config) {
+		c.use("WithCompleter")
+		c.completer = completer
+	}
+}
+
+// WithMinItems sets the minimum number of items of a list question.
+// Without it, at least one item is required, unless the question has a default value or is Optional.
+func WithMinItems(count int) Option {
+	return func(c *config) {
+		c.use("WithMinItems")
+		c.minItems = count
+	}
+}
+
+// WithMaxItems sets the maximum number of items of a list question, the question ends once it's reached.
+func WithMaxItems(count int) Option {
+	return func(c *config) {
+		c.use("WithMaxItems")
+		c.maxItems = count
+	}
+}
+
+// Deduplicate drops the repeated items of a list question.
+func Deduplicate() Option {
+	return func(c *config) {
+		c.use("Deduplicate")
+		c.deduplicate = true
+	}
+}
+
+// SingleLine asks for the items of a list question in a single line, separated by commas or whitespace.
+// Items containing separators can be quoted with double or single quotes, and a backslash escapes the next character (except in single quotes).
+func SingleLine() Option {
+	return func(c *config) {
+		c.use("SingleLine")
+		c.singleLine = true
+	}
+}
+
+// WithSecretFlag asks whether each environment variable is a secret, see AskForEnvVars.
+func WithSecretFlag() Option {
+	return func(c *config) {
+		c.use("WithSecretFlag")
+		c.secretFlag = true
+	}
+}
+
+// WithReview lets the user review the entries of a group and delete some of them before finishing, see AskForGroup.
+func WithReview() Option {
+	return func(c *config) {
+		c.use("WithReview")
+		c.review = true
+	}
+}
+
+// WithPageSize lists the options of a select question page by page in line mode, if there are more than the page size.
+// The user can move between the pages with "n" and "p", filter the options with "/text" and select any option by its number.
+func WithPageSize(size int) Option {
+	return func(c *config) {
+		c.use("WithPageSize")
+		c.pageSize = size
+	}
+}
+
+// SelectAnyNode allows selecting the options having sub-options too, see SelectFromTree. By default only the leaves can be selected.
+func SelectAnyNode() Option {
+	return func(c *config) {
+		c.use("SelectAnyNode")
+		c.anyNode = true
+	}
+}
+
+// Keypress answers a bool question with a single key press in TTY mode (like "y" or "n"), without Enter.
+// In line mode the answer is typed in as usual.
+func Keypress() Option {
+	return func(c *config) {
+		c.use("Keypress")
+		c.keypress = true
+	}
+}
+
+// WithTimeout continues without a key press once the timeout expires, see Pause.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.use("WithTimeout")
+		c.timeout = timeout
+	}
+}
+
+// WithAbortKeys sets the keys which abort a Pause with ErrInterrupted, like 'q'.
+// In line mode the key has to be typed in alone, followed by Enter.
+func WithAbortKeys(keys ...rune) Option {
+	return func(c *config) {
+		c.use("WithAbortKeys")
+		c.abortKeys = append(c.abortKeys, keys...)
+	}
+}
+
+// WithExternalPager shows the text of Page with the pager set in $PAGER (if set) in TTY mode, instead of the built-in one.
+func WithExternalPager() Option {
+	return func(c *config) {
+		c.use("WithExternalPager")
+		c.externalPager = true
+	}
+}
+
+// SideBySide shows the changes of ConfirmDiff side by side, instead of a unified diff.
+func SideBySide() Option {
+	return func(c *config) {
+		c.use("SideBySide")
+		c.sideBySide = true
+	}
+}
+
+// WithFileName sets the name of the file changed by ConfirmDiff, the content is edited in a temporary file
+// with the same name and extension, so that the editor can highlight its syntax.
+func WithFileName(name string) Option {
+	return func(c *config) {
+		c.use("WithFileName")
+		c.fileName = name
+	}
+}
+
+// WithStep sets how much Up / Down changes the value of AskForIntInRange in TTY mode, 1 by default.
+func WithStep(step int64) Option {
+	return func(c *config) {
+		c.use("WithStep")
+		c.step = step
+	}
+}
+
+// WithPlaceholder sets an example answer, shown greyed-out in the empty input in TTY mode and as an "e.g." hint in line mode.
+// Unlike the default value, the placeholder is never returned as the answer.
+func WithPlaceholder(placeholder string) Option {
+	return func(c *config) {
+		c.use("WithPlaceholder")
+		c.placeholder = placeholder
+	}
+}
+
+// Secret marks the answer as sensitive: it's masked while typing in TTY mode and in the summary line.
+func Secret() Option {
+	return func(c *config) {
+		c.use("Secret")
+		c.secret = true
+	}
+}
+
+// WithSummaryFormatter sets how the answered question is rendered in TTY mode,
+// by default it's collapsed into a "✓ Question: answer" line.
+func WithSummaryFormatter(formatter SummaryFormatter) Option {
+	return func(c *config) {
+		c.use("WithSummaryFormatter")
+		c.summaryFormatter = formatter
+	}
+}
+
+// Optional accepts an empty answer for a question without default value, in which case the zero value is returned.
+func Optional() Option {
+	return func(c *config) {
+		c.use("Optional")
+		c.optional = true
+	}
+}
+
+// WithTheme sets the theme of the question, instead of the one set by SetTheme.
+func WithTheme(questionTheme Theme) Option {
+	return func(c *config) {
+		c.theme = &questionTheme
+	}
+}
+
+// WithBoolVocabulary sets the vocabulary of a bool question, instead of the one set by SetBoolVocabulary.
+func WithBoolVocabulary(vocabulary BoolVocabulary) Option {
+	return func(c *config) {
+		c.use("WithBoolVocabulary")
+		c.vocabulary = &vocabulary
+	}
+}
+
+// WithLocale sets the locale of the question's messages, instead of the one set by SetLocale.
+func WithLocale(questionLocale string) Option {
+	return func(c *config) {
+		c.locale = normalizeLocale(questionLocale)
+	}
+}
+
+// The options supported by the askers, besides WithReader, WithWriter, WithTheme and WithLocale which every asker supports.
+var (
+	questionOptions = []string{"WithDefault", "Optional", "WithValidator", "WithHelp", "WithHelpURL", "WithSummaryFormatter"}
+	textOptions     = []string{"WithPlaceholder", "WithCharFilter", "WithSuggestions", "WithCompleter", "Secret"}
+	boolOptions     = []string{"WithBoolVocabulary", "Keypress"}
+	listOptions     = []string{"WithMinItems", "WithMaxItems"}
+)
+
+// checkOptions returns an error if an option is set which the asker doesn't support, instead of ignoring it.
+func (c *config) checkOptions(asker string, supported ...[]string) error {
+	for _, name := range c.used {
+		ok := false
+		for _, names := range supported {
+			for _, supportedName := range names {
+				if name == supportedName {
+					ok = true
+				}
+			}
+		}
+		if !ok {
+			return fmt.Errorf("invalid option for %s: %s is not supported", asker, name)
+		}
+	}
+	return nil
+}
+
+func (c *config) use(name string) {
+	c.used = append(c.used, name)
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		reader: os.Stdin,
+		writer: os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.console == nil {
+		c.console = detectConsole(c.reader, c.writer)
+	}
+	return c
+}
+
+// stdinReader is shared by the questions reading from os.Stdin, so that nothing read ahead is lost between questions.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// input returns the buffered reader of the answers.
+// If the reader is already a *bufio.Reader it's used as is, which allows asking more questions from the same reader.
+func (c *config) input() *bufio.Reader {
+	if c.in == nil {
+		if c.reader == os.Stdin {
+			c.in = stdinReader
+		} else {
+			c.in = bufio.NewReader(c.reader)
+		}
+	}
+	return c.in
+}
+
+func (c *config) hasHelp() bool {
+	return c.help != "" || c.helpURL != ""
+}
+
+func (c *config) currentTheme() Theme {
+	if c.theme != nil {
+		return *c.theme
+	}
+	if setTheme := globalTheme(); setTheme != nil {
+		return *setTheme
+	}
+	if colorSupported(c.writer) {
+		return ColorTheme
+	}
+	return PlainTheme
+}
+
+func (c *config) currentBoolVocabulary() BoolVocabulary {
+	if c.vocabulary != nil {
+		return *c.vocabulary
+	}
+	if setVocabulary := globalBoolVocabulary(); setVocabulary != nil {
+		return *setVocabulary
+	}
+	return localeBoolVocabulary(c.currentLocale())
+}
+
+func (c *config) currentLocale() string {
+	if c.locale != "" {
+		return c.locale
+	}
+	return currentLocale()
+}
+
+func (c *config) message(id MessageID, args ...interface{}) string {
+	return messageInLocale(c.currentLocale(), id, args...)
+}
+
+// printf prints to the question's writer. Printing is best effort, like printing to the standard output.
+func (c *config) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(c.writer, format, args...)
+}
+
+func (c *config) println(args ...interface{}) {
+	_, _ = fmt.Fprintln(c.writer, args...)
+}
+
+// validate runs the validators on a non-empty answer.
+func (c *config) validate(answer string) error {
+	if answer == "" {
+		return nil
+	}
+	for _, validator := range c.validators {
+		if err := validator(answer); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return err
+			}
+			return &ValidationError{Input: answer, Err: err}
+		}
+	}
+	return nil
+}
+
+// defaultString returns the default value of a string question.
+func (c *config) defaultString() (string, error) {
+	value, ok := c.defaultValue.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid default value (%v) for a string question, should be a string", c.defaultValue)
+	}
+	return value, nil
+}
+
+// defaultStrings returns the default value of a string list question.
+func (c *config) defaultStrings() ([]string, error) {
+	value, ok := c.defaultValue.([]string)
+	if !ok {
+		return nil, fmt.Errorf("invalid default value (%v) for a string list question, should be a []string", c.defaultValue)
+	}
+	return value, nil
+}
+
+// defaultEnvVars returns the default value of an environment variable question.
+func (c *config) defaultEnvVars() (EnvVars, error) {
+	switch value := c.defaultValue.(type) {
+	case EnvVars:
+		return value, nil
+	case []EnvVar:
+		return value, nil
+	}
+	return nil, fmt.Errorf("invalid default value (%v) for an environment variable question, should be EnvVars", c.defaultValue)
+}
+
+// defaultInt returns the default value of an int question.
+func (c *config) defaultInt() (int64, error) {
+	switch value := c.defaultValue.(type) {
+	case int:
+		return int64(value), nil
+	case int8:
+		return int64(value), nil
+	case int16:
+		return int64(value), nil
+	case int32:
+		return int64(value), nil
+	case int64:
+		return value, nil
+	case uint:
+		return c.defaultUint(uint64(value))
+	case uint64:
+		return c.defaultUint(value)
+	case uint8:
+		return int64(value), nil
+	case uint16:
+		return int64(value), nil
+	case uint32:
+		return int64(value), nil
+	}
+	return 0, fmt.Errorf("invalid default value (%v) for an int question, should be an integer", c.defaultValue)
+}
+
+func (c *config) defaultUint(value uint64) (int64, error) {
+	if value > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid default value (%v) for an int question, greater than the maximum int64", c.defaultValue)
+	}
+	return int64(value), nil
+}
+
+// defaultBool returns the default value of a bool question.
+func (c *config) defaultBool() (bool, error) {
+	value, ok := c.defaultValue.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid default value (%v) for a bool question, should be a bool", c.defaultValue)
+	}
+	return value, nil
+}
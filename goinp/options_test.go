@@ -0,0 +1,191 @@
+package goinp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForStringOptions(t *testing.T) {
+	t.Log("Default value")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Enter some text", WithReader(strings.NewReader("\n")), WithWriter(&out), WithDefault("default"), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "default", res)
+		require.Equal(t, "Enter some text [default] : \n", out.String())
+	}
+
+	t.Log("Empty default value is still a default")
+	{
+		res, err := AskForString("Enter some text", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(""))
+		require.NoError(t, err)
+		require.Equal(t, "", res)
+	}
+
+	t.Log("Optional")
+	{
+		res, err := AskForString("Enter some text", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), Optional())
+		require.NoError(t, err)
+		require.Equal(t, "", res)
+	}
+
+	t.Log("Invalid default value")
+	{
+		_, err := AskForString("Enter some text", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(1))
+		require.Error(t, err)
+	}
+
+	t.Log("Validator")
+	{
+		noSpaces := func(answer string) error {
+			if strings.Contains(answer, " ") {
+				return fmt.Errorf("should not contain spaces")
+			}
+			return nil
+		}
+
+		res, err := AskForString("Enter an ID", WithReader(strings.NewReader("my-id")), WithWriter(&bytes.Buffer{}), WithValidator(noSpaces))
+		require.NoError(t, err)
+		require.Equal(t, "my-id", res)
+
+		_, err = AskForString("Enter an ID", WithReader(strings.NewReader("my id")), WithWriter(&bytes.Buffer{}), WithValidator(noSpaces))
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, "my id", validationErr.Input)
+		require.EqualError(t, err, "should not contain spaces")
+	}
+
+	t.Log("Questions sharing a reader")
+	{
+		reader := bufio.NewReader(strings.NewReader("first\nsecond\n"))
+
+		res, err := AskForString("Enter some text", WithReader(reader), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, "first", res)
+
+		res, err = AskForString("Enter some text", WithReader(reader), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, "second", res)
+	}
+}
+
+func TestAskForIntOptions(t *testing.T) {
+	t.Log("NO default value")
+	{
+		var out bytes.Buffer
+		_, err := AskForInt("Enter a number", WithReader(strings.NewReader("\n")), WithWriter(&out), WithTheme(PlainTheme))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+		require.Equal(t, "Enter a number : \n", out.String())
+	}
+
+	t.Log("Default value")
+	{
+		var out bytes.Buffer
+		res, err := AskForInt("Enter a number", WithReader(strings.NewReader("\n")), WithWriter(&out), WithTheme(PlainTheme), WithDefault(int64(0)))
+		require.NoError(t, err)
+		require.Equal(t, int64(0), res)
+		require.Equal(t, "Enter a number [0] : \n", out.String())
+	}
+
+	t.Log("Validator")
+	{
+		_, err := AskForInt("Enter a number", WithReader(strings.NewReader("11")), WithWriter(&bytes.Buffer{}), WithValidator(func(answer string) error {
+			return &ValidationError{Input: answer, Err: ErrOutOfRange}
+		}))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+	}
+
+	t.Log("Unsigned default value")
+	{
+		res, err := AskForInt("Enter a number", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(uint64(3)))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res)
+
+		_, err = AskForInt("Enter a number", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(uint64(math.MaxUint64)))
+		require.EqualError(t, err, "invalid default value (18446744073709551615) for an int question, greater than the maximum int64")
+	}
+}
+
+func TestUnsupportedOptions(t *testing.T) {
+	t.Log("An option the asker doesn't support is rejected, instead of being ignored")
+	{
+		_, err := AskForString("Name", WithReader(strings.NewReader("bob\n")), WithWriter(&bytes.Buffer{}), WithStep(5))
+		require.EqualError(t, err, "invalid option for AskForString: WithStep is not supported")
+
+		_, err = AskForBool("Deploy?", WithReader(strings.NewReader("y\n")), WithWriter(&bytes.Buffer{}), WithPageSize(10))
+		require.EqualError(t, err, "invalid option for AskForBool: WithPageSize is not supported")
+
+		err = Pause("Press Enter", WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault("x"))
+		require.EqualError(t, err, "invalid option for Pause: WithDefault is not supported")
+	}
+
+	t.Log("The options every asker supports")
+	{
+		res, err := AskForString("Name", WithReader(strings.NewReader("bob\n")), WithWriter(&bytes.Buffer{}), WithTheme(PlainTheme), WithLocale("en"))
+		require.NoError(t, err)
+		require.Equal(t, "bob", res)
+	}
+}
+
+func TestAskForBoolOptions(t *testing.T) {
+	t.Log("Vocabulary")
+	{
+		var out bytes.Buffer
+		res, err := AskForBool("Enable?", WithReader(strings.NewReader("on")), WithWriter(&out), WithTheme(PlainTheme),
+			WithBoolVocabulary(NewBoolVocabulary([]string{"on"}, []string{"off"})), WithDefault(false))
+		require.NoError(t, err)
+		require.True(t, res)
+		require.Equal(t, "Enable? [on/OFF]: \n", out.String())
+	}
+
+	t.Log("Optional")
+	{
+		res, err := AskForBool("Enable?", WithReader(strings.NewReader("")), WithWriter(&bytes.Buffer{}), Optional())
+		require.True(t, errors.Is(err, ErrEOF))
+		require.False(t, res)
+	}
+}
+
+func TestSelectFromStringsOptions(t *testing.T) {
+	options := []string{"first", "second", "third"}
+
+	t.Log("Default option")
+	{
+		var out bytes.Buffer
+		res, err := SelectFromStrings("Select something", options, WithReader(strings.NewReader("")), WithWriter(&out), WithTheme(PlainTheme), WithDefault("second"))
+		require.NoError(t, err)
+		require.Equal(t, "second", res)
+		require.Contains(t, out.String(), "(type in the option's number, then hit Enter) [2] : ")
+	}
+
+	t.Log("Default option's number")
+	{
+		res, err := SelectFromStrings("Select something", options, WithReader(strings.NewReader("")), WithWriter(&bytes.Buffer{}), WithDefault(3))
+		require.NoError(t, err)
+		require.Equal(t, "third", res)
+	}
+
+	t.Log("Default option not in the list")
+	{
+		_, err := SelectFromStrings("Select something", options, WithReader(strings.NewReader("")), WithWriter(&bytes.Buffer{}), WithDefault("fourth"))
+		require.Error(t, err)
+	}
+
+	t.Log("Locale")
+	{
+		RegisterCatalog("hu", Catalog{MsgSelectFromList: "Válassz a listából:"})
+		defer delete(catalogs, "hu")
+
+		var out bytes.Buffer
+		_, err := SelectFromStrings("Select something", options, WithReader(strings.NewReader("1")), WithWriter(&out), WithLocale("hu_HU.UTF-8"))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "Válassz a listából:")
+	}
+}
@@ -0,0 +1,248 @@
+package goinp
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//=======================================
+// Order
+//=======================================
+
+// AskForOrder asks the user to put the items in order, and returns them in the new order.
+// In line mode the new order is given by the numbers of the items (like "3,1,2"), in TTY mode the items can be
+// moved with the arrow keys too (Space picks up and drops the highlighted item). Every item has to be listed exactly once.
+// An empty answer keeps the current order. The validators get the items in the new order, joined by ", ".
+func AskForOrder(messageToPrint string, items []string, opts ...Option) ([]string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForOrder", []string{"WithValidator", "WithHelp", "WithHelpURL", "WithSummaryFormatter"}); err != nil {
+		return nil, err
+	}
+	return askForOrder(c, messageToPrint, items)
+}
+
+func askForOrder(c *config, messageToPrint string, items []string) (ordered []string, err error) {
+	defer func() { c.finishQuestion(messageToPrint, strings.Join(ordered, ", "), err) }()
+
+	var order []int
+	if c.console != nil {
+		order, err = c.orderInMenu(messageToPrint, items)
+	} else {
+		order, err = c.orderInList(messageToPrint, items)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return orderedItems(items, order), nil
+}
+
+// orderInList asks for the numbers of the items in the new order in line mode.
+func (c *config) orderInList(messageToPrint string, items []string) ([]int, error) {
+	theme := c.currentTheme()
+
+	header := []string{theme.Prompt.render(messageToPrint), theme.Help.render(c.message(MsgOrderItems))}
+	for idx, item := range items {
+		header = append(header, "["+strconv.Itoa(idx+1)+"] : "+item)
+	}
+
+	current := identityOrder(len(items))
+	p := linePrompt{
+		header: header,
+		prompt: theme.Help.render(c.message(MsgOrderNumbers)),
+		hint:   formatOrder(current),
+		check: func(answer string) error {
+			_, err := c.parseOrder(items, answer)
+			return err
+		},
+		checkEmpty: func() error {
+			return c.validate(strings.Join(items, ", "))
+		},
+	}
+
+	answer, err := c.askLine(p)
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		return current, nil
+	}
+	return c.parseOrder(items, answer)
+}
+
+// parseOrder parses the numbers of the items, separated by commas or whitespace, into the indexes of the items
+// in the new order. Every item has to be listed exactly once, and the new order has to pass the validators.
+func (c *config) parseOrder(items []string, answer string) ([]int, error) {
+	fields := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	listed := make([]bool, len(items))
+	var order []int
+	for _, field := range fields {
+		num, err := selectNumber(c, len(items), field)
+		if err != nil {
+			return nil, err
+		}
+		if listed[num-1] {
+			return nil, &ValidationError{Input: answer, Message: c.message(MsgOrderRepeated, num), Err: ErrInvalidOption}
+		}
+		listed[num-1] = true
+		order = append(order, num-1)
+	}
+
+	var missing []int
+	for idx, ok := range listed {
+		if !ok {
+			missing = append(missing, idx)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &ValidationError{Input: answer, Message: c.message(MsgOrderMissing, formatOrder(missing)), Err: ErrInvalidOption}
+	}
+
+	if err := c.validate(strings.Join(orderedItems(items, order), ", ")); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func identityOrder(count int) []int {
+	order := make([]int, count)
+	for idx := range order {
+		order[idx] = idx
+	}
+	return order
+}
+
+// formatOrder returns the numbers of the items, separated by commas.
+func formatOrder(order []int) string {
+	numbers := make([]string, len(order))
+	for idx, itemIdx := range order {
+		numbers[idx] = strconv.Itoa(itemIdx + 1)
+	}
+	return strings.Join(numbers, ",")
+}
+
+func orderedItems(items []string, order []int) []string {
+	ordered := make([]string, len(order))
+	for idx, itemIdx := range order {
+		ordered[idx] = items[itemIdx]
+	}
+	return ordered
+}
+
+//=======================================
+// Order menu
+//=======================================
+
+// orderMenu lists the items in their current order in the menu. Space picks up (and drops) the highlighted item,
+// which is then moved by the arrow keys. The numbers of the items can be typed in too, like in line mode.
+type orderMenu struct {
+	m     *menu
+	items []string
+	// order is the indexes of the items in the current order.
+	order []int
+	// grabbed is true if the highlighted item is moved by the arrow keys.
+	grabbed bool
+}
+
+// orderInMenu asks for the new order in TTY mode.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) orderInMenu(messageToPrint string, items []string) ([]int, error) {
+	theme := c.currentTheme()
+
+	o := &orderMenu{items: items, order: identityOrder(len(items))}
+	o.m = &menu{
+		title: []string{
+			theme.Prompt.render(messageToPrint),
+			theme.Help.render(c.message(MsgOrderItems)),
+			theme.Help.render(c.message(MsgOrderMoveHint)),
+		},
+		prompt: c.message(MsgOrderNumbers),
+		check: func(answer string) error {
+			_, err := c.parseOrder(items, answer)
+			return err
+		},
+		keys:   o.keys,
+		rows:   o.rows,
+		answer: func(current int) string { return formatOrder(o.order) },
+		find:   func(answer string) int { return -1 },
+		accept: func(r rune) bool { return r == ',' || r == ' ' || (r >= '0' && r <= '9') },
+	}
+
+	answer, err := c.runMenu(o.m, "")
+	if err != nil {
+		return nil, err
+	}
+	return c.parseOrder(items, answer)
+}
+
+// keys picks up and drops the highlighted item, and moves the picked up item.
+func (o *orderMenu) keys(k key) bool {
+	m := o.m
+	if len(m.input) > 0 || len(o.order) == 0 {
+		return false
+	}
+
+	if k.code == keyRune && k.r == ' ' {
+		o.grabbed = !o.grabbed
+		m.marker = ""
+		if o.grabbed {
+			m.marker = "↕ "
+		}
+		return true
+	}
+	if !o.grabbed {
+		return false
+	}
+
+	target := m.current
+	switch k.code {
+	case keyUp:
+		target--
+	case keyDown:
+		target++
+	case keyPageUp:
+		target -= m.visibleRows()
+	case keyPageDown:
+		target += m.visibleRows()
+	case keyHome:
+		target = 0
+	case keyEnd:
+		target = len(o.order) - 1
+	case keyEnter:
+		// dropped if the order is accepted
+		if m.check(m.currentAnswer()) == nil {
+			o.grabbed = false
+			m.marker = ""
+		}
+		return false
+	default:
+		return false
+	}
+
+	target = maxInt(minInt(target, len(o.order)-1), 0)
+	item := o.order[m.current]
+	o.order = append(o.order[:m.current], o.order[m.current+1:]...)
+	o.order = append(o.order[:target], append([]int{item}, o.order[target:]...)...)
+	m.current = target
+	m.err = nil
+	return true
+}
+
+func (o *orderMenu) rows(current int) ([]string, []int) {
+	theme := o.m.c.currentTheme()
+
+	var rows []string
+	var selectable []int
+	for idx, itemIdx := range o.order {
+		row := "[" + strconv.Itoa(itemIdx+1) + "] : " + o.items[itemIdx]
+		if idx == current {
+			row = theme.Selected.render(row)
+		}
+		rows = append(rows, row)
+		selectable = append(selectable, idx)
+	}
+	return rows, selectable
+}
@@ -0,0 +1,114 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForOrder(t *testing.T) {
+	targets := []string{"staging", "production", "canary"}
+
+	t.Log("Line mode")
+	{
+		var out bytes.Buffer
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("3, 1 2\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, []string{"canary", "staging", "production"}, res)
+		require.Equal(t, `Deployment order
+Put the items in order:
+[1] : staging
+[2] : production
+[3] : canary
+(type in the numbers in the new order, like 3,1,2, then hit Enter) [1,2,3] : 
+`, out.String())
+	}
+
+	t.Log("Empty answer keeps the order")
+	{
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, targets, res)
+	}
+
+	t.Log("Not a permutation")
+	{
+		_, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("3,1,3\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid order: 3 is listed more than once")
+
+		_, err = AskForOrder("Deployment order", targets, WithReader(strings.NewReader("2\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "invalid order: missing 1,3")
+
+		_, err = AskForOrder("Deployment order", targets, WithReader(strings.NewReader("3,1,4\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+	}
+
+	t.Log("Validator gets the new order")
+	{
+		lastIsProduction := func(answer string) error {
+			if !strings.HasSuffix(answer, "production") {
+				return fmt.Errorf("production has to be the last")
+			}
+			return nil
+		}
+		_, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithValidator(lastIsProduction))
+		require.EqualError(t, err, "production has to be the last")
+
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("1 3 2\n")), WithWriter(&bytes.Buffer{}), WithValidator(lastIsProduction))
+		require.NoError(t, err)
+		require.Equal(t, []string{"staging", "canary", "production"}, res)
+	}
+}
+
+func TestAskForOrderMenu(t *testing.T) {
+	targets := []string{"staging", "production", "canary"}
+
+	t.Log("Move an item with the arrow keys")
+	{
+		var out bytes.Buffer
+		// down to production, pick it up, move it to the end, drop it
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("\x1b[B \x1b[B\x1b[B \r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, []string{"staging", "canary", "production"}, res)
+		require.Contains(t, out.String(), "  [1] : staging\r\n  [3] : canary\r\n↕ [2] : production\r\n")
+		require.Equal(t, "✓ Deployment order: staging, canary, production\n", lastFrame(out.String()))
+	}
+
+	t.Log("Home moves the grabbed item to the top")
+	{
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("\x1b[A \x1b[H\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"canary", "staging", "production"}, res)
+	}
+
+	t.Log("The grabbed item stays picked up if the order is rejected")
+	{
+		notFirst := func(answer string) error {
+			if strings.HasPrefix(answer, "staging") {
+				return errors.New("staging can't be deployed first")
+			}
+			return nil
+		}
+
+		var out bytes.Buffer
+		// pick up staging, Enter is rejected, Page Down moves it to the end
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader(" \r\x1b[6~\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithValidator(notFirst))
+		require.NoError(t, err)
+		require.Equal(t, []string{"production", "canary", "staging"}, res)
+		require.Contains(t, out.String(), "↕ [1] : staging\r\n  [2] : production\r\n  [3] : canary\r\n(type in the numbers in the new order, like 3,1,2, then hit Enter) : \r\nstaging can't be deployed first")
+	}
+
+	t.Log("Typed in order")
+	{
+		var out bytes.Buffer
+		res, err := AskForOrder("Deployment order", targets, WithReader(strings.NewReader("2,2\x7f3,1\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, []string{"production", "canary", "staging"}, res)
+		require.Contains(t, out.String(), "invalid order: 2 is listed more than once")
+	}
+}
@@ -0,0 +1,286 @@
+package goinp
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+//=======================================
+// Pager
+//=======================================
+
+// Page shows a long text, like licence terms. In TTY mode the text is scrolled within the terminal if it doesn't fit:
+// Space (or Page Down) shows the next page, b (or Page Up) the previous one, the arrow keys scroll by lines,
+// / searches for a text (n repeats the search) and q quits. Space on the last page quits too.
+// With WithExternalPager the pager set in $PAGER is used instead. In line mode the text is printed as is.
+func Page(text string, opts ...Option) error {
+	c := newConfig(opts)
+	if err := c.checkOptions("Page", []string{"WithExternalPager"}); err != nil {
+		return err
+	}
+	return page(c, text, false)
+}
+
+// AskForBoolWithPager shows the text like Page, then asks the yes/no question like AskForBool.
+// The page shown last is left on the screen above the question.
+func AskForBoolWithPager(messageToPrint, text string, opts ...Option) (bool, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForBoolWithPager", questionOptions, boolOptions, []string{"WithExternalPager"}); err != nil {
+		return false, err
+	}
+	// the text stays on the screen above the question
+	if err := page(c, text, true); err != nil {
+		return false, err
+	}
+	return askForBool(c, messageToPrint)
+}
+
+// page shows the text, the pager leaves the last page shown on the screen if keep is set, otherwise it's erased.
+func page(c *config, text string, keep bool) error {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if c.console == nil {
+		for _, line := range lines {
+			c.println(line)
+		}
+		return nil
+	}
+
+	if command := os.Getenv("PAGER"); c.externalPager && command != "" {
+		return c.runExternalPager(command, text)
+	}
+
+	width, height := c.console.size()
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(line, width)...)
+	}
+	// it fits, no need to scroll
+	if len(wrapped) < height {
+		for _, line := range lines {
+			c.println(line)
+		}
+		return nil
+	}
+
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	p := &pager{c: c, screen: &screen{c: c}, lines: wrapped, match: -1, keep: keep}
+	return p.run()
+}
+
+// runExternalPager pipes the text into the pager command, run by the shell like git does.
+func (c *config) runExternalPager(command, text string) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = c.writer
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// wrapLine splits the line into rows fitting the width, keeping the ANSI escape sequences in the row they belong to.
+func wrapLine(line string, width int) []string {
+	var rows []string
+	var row strings.Builder
+	used := 0
+
+	runes := []rune(line)
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		if r == '\x1b' && idx+1 < len(runes) && runes[idx+1] == '[' {
+			end := idx + 2
+			for end < len(runes) && (runes[end] < 0x40 || runes[end] > 0x7e) {
+				end++
+			}
+			if end < len(runes) {
+				end++
+			}
+			row.WriteString(string(runes[idx:end]))
+			idx = end - 1
+			continue
+		}
+
+		if used+runeWidth(r) > width {
+			rows = append(rows, row.String())
+			row.Reset()
+			used = 0
+		}
+		row.WriteRune(r)
+		used += runeWidth(r)
+	}
+	return append(rows, row.String())
+}
+
+// pager scrolls the lines in raw mode.
+type pager struct {
+	c      *config
+	screen *screen
+	lines  []string
+	// keep leaves the last page shown on the screen when the pager quits.
+	keep bool
+
+	// top is the index of the first line shown.
+	top int
+	// searching is set while the search text is typed in, query is the text searched for last.
+	searching bool
+	search    []rune
+	query     string
+	// match is the index of the line found last, -1 if none.
+	match    int
+	notFound bool
+}
+
+func (p *pager) run() error {
+	input := p.c.input()
+	for {
+		p.render()
+
+		k, err := readKey(input)
+		if err == ErrEOF {
+			p.quit()
+			return nil
+		} else if err != nil {
+			p.screen.clear()
+			return err
+		}
+		if k.code == keyInterrupt {
+			p.screen.clear()
+			return ErrInterrupted
+		}
+
+		if p.searching {
+			p.searchKey(k)
+			continue
+		}
+
+		p.notFound = false
+		switch {
+		case k.code == keyRune && (k.r == ' ' || k.r == 'f'), k.code == keyPageDown:
+			if p.top >= p.lastTop() && k.code == keyRune && k.r == ' ' {
+				p.quit()
+				return nil
+			}
+			p.scroll(p.pageHeight())
+		case k.code == keyRune && k.r == 'b', k.code == keyPageUp:
+			p.scroll(-p.pageHeight())
+		case k.code == keyRune && k.r == 'j', k.code == keyDown, k.code == keyEnter:
+			p.scroll(1)
+		case k.code == keyRune && k.r == 'k', k.code == keyUp:
+			p.scroll(-1)
+		case k.code == keyRune && k.r == 'g', k.code == keyHome:
+			p.top = 0
+		case k.code == keyRune && k.r == 'G', k.code == keyEnd:
+			p.top = p.lastTop()
+		case k.code == keyRune && k.r == '/':
+			p.searching = true
+			p.search = nil
+		case k.code == keyRune && k.r == 'n':
+			p.find()
+		case k.code == keyRune && k.r == 'q', k.code == keyEscape, k.code == keyEOF:
+			p.quit()
+			return nil
+		}
+	}
+}
+
+// quit erases the pager, or replaces it with the page shown (without the status line) if keep is set.
+func (p *pager) quit() {
+	if !p.keep {
+		p.screen.clear()
+		return
+	}
+	p.screen.closeWith(p.page())
+}
+
+// searchKey edits the search text, Enter searches for it and Escape cancels the search.
+func (p *pager) searchKey(k key) {
+	switch k.code {
+	case keyEnter:
+		p.searching = false
+		if len(p.search) > 0 {
+			p.query = string(p.search)
+		}
+		p.find()
+	case keyEscape:
+		p.searching = false
+	case keyBackspace:
+		if len(p.search) == 0 {
+			p.searching = false
+		} else {
+			p.search = p.search[:len(p.search)-1]
+		}
+	case keyRune:
+		p.search = append(p.search, k.r)
+	}
+}
+
+// find scrolls to the next line containing the query (ignoring the case), after the previous match
+// or the first line shown.
+func (p *pager) find() {
+	if p.query == "" {
+		return
+	}
+	from := p.top
+	if p.match >= p.top {
+		from = p.match + 1
+	}
+
+	query := strings.ToLower(p.query)
+	for idx := from; idx < len(p.lines); idx++ {
+		if strings.Contains(strings.ToLower(stripANSI(p.lines[idx])), query) {
+			p.match = idx
+			p.top = minInt(idx, p.lastTop())
+			return
+		}
+	}
+	p.notFound = true
+}
+
+func (p *pager) scroll(lines int) {
+	p.top = maxInt(minInt(p.top+lines, p.lastTop()), 0)
+}
+
+// pageHeight is the number of lines shown, above the status line.
+func (p *pager) pageHeight() int {
+	_, height := p.c.console.size()
+	return maxInt(height-1, 1)
+}
+
+func (p *pager) lastTop() int {
+	return maxInt(len(p.lines)-p.pageHeight(), 0)
+}
+
+// page returns the lines shown, the search match highlighted.
+func (p *pager) page() []string {
+	end := minInt(p.top+p.pageHeight(), len(p.lines))
+	lines := append([]string{}, p.lines[p.top:end]...)
+	if p.match >= p.top && p.match < end {
+		lines[p.match-p.top] = p.c.currentTheme().Selected.render(stripANSI(lines[p.match-p.top]))
+	}
+	return lines
+}
+
+func (p *pager) render() {
+	theme := p.c.currentTheme()
+
+	lines := p.page()
+	end := p.top + len(lines)
+
+	// the status line must not wrap, it would scroll the page
+	width, _ := p.c.console.size()
+	var status string
+	switch {
+	case p.searching:
+		status = "/" + string(p.search)
+	case p.notFound:
+		status = theme.Error.render(truncate(p.c.message(MsgPagerNotFound, p.query), width-1))
+	default:
+		status = theme.Help.render(truncate(p.c.message(MsgPagerStatus, p.top+1, end, len(p.lines)), width-1))
+	}
+	lines = append(lines, status)
+	p.screen.render(lines, len(lines)-1, displayWidth(status))
+}
@@ -0,0 +1,75 @@
+package goinp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testText(count int) string {
+	var lines []string
+	for idx := 1; idx <= count; idx++ {
+		lines = append(lines, fmt.Sprintf("line %d", idx))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestPage(t *testing.T) {
+	t.Log("Line mode - the text is printed, then the question is asked")
+	{
+		var out bytes.Buffer
+		res, err := AskForBoolWithPager("Accept the licence?", testText(3), WithReader(strings.NewReader("yes\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.True(t, res)
+		require.Equal(t, "line 1\nline 2\nline 3\nAccept the licence? [yes/no] : \n", out.String())
+	}
+
+	t.Log("The text fits the terminal")
+	{
+		var out bytes.Buffer
+		err := Page(testText(3), WithReader(strings.NewReader("")), WithWriter(&out), withConsole(fakeConsole{80, 5}))
+		require.NoError(t, err)
+		require.Equal(t, "line 1\nline 2\nline 3\n", out.String())
+	}
+
+	t.Log("Scrolling")
+	{
+		var out bytes.Buffer
+		res, err := AskForBoolWithPager("Accept the licence?", testText(10), WithReader(strings.NewReader(" qy")), WithWriter(&out), withConsole(fakeConsole{80, 5}), WithTheme(PlainTheme), Keypress())
+		require.NoError(t, err)
+		require.True(t, res)
+		require.Contains(t, out.String(), "\r\x1b[Jline 1\r\nline 2\r\nline 3\r\nline 4\r\nlines 1-4 of 10 (Space: next page, b: previous page, /: search, q: quit)")
+		require.Contains(t, out.String(), "\r\x1b[Jline 5\r\nline 6\r\nline 7\r\nline 8\r\nlines 5-8 of 10 (Space: next page, b: previous page, /: search, q: quit)")
+		// the page shown last stays above the question, without the status line
+		require.Contains(t, out.String(), "\r\x1b[Jline 5\r\nline 6\r\nline 7\r\nline 8\r\x1b[6C\r\n\r\x1b[JAccept the licence? [yes/no] : ")
+		require.Equal(t, "✓ Accept the licence? yes\n", lastFrame(out.String()))
+	}
+
+	t.Log("Search, Space quits on the last page")
+	{
+		var out bytes.Buffer
+		err := Page(testText(10), WithReader(strings.NewReader("/LINE 9\rn  ")), WithWriter(&out), withConsole(fakeConsole{80, 5}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "\r\x1b[Jline 7\r\nline 8\r\nline 9\r\nline 10\r\nlines 7-10 of 10")
+		require.Contains(t, out.String(), "line 10\r\nnot found: LINE 9")
+		require.True(t, strings.HasSuffix(out.String(), "\x1b[4A\r\x1b[J\r"))
+	}
+
+	t.Log("Long lines are wrapped")
+	{
+		require.Equal(t, []string{"abc", "de"}, wrapLine("abcde", 3))
+		require.Equal(t, []string{"\x1b[31mテ", "ス\x1b[0m"}, wrapLine("\x1b[31mテス\x1b[0m", 3))
+	}
+}
+
+func TestExternalPager(t *testing.T) {
+	t.Setenv("PAGER", "tr a-z A-Z")
+
+	var out bytes.Buffer
+	err := Page(testText(10), WithReader(strings.NewReader("")), WithWriter(&out), withConsole(fakeConsole{80, 5}), WithExternalPager())
+	require.NoError(t, err)
+	require.Equal(t, strings.ToUpper(testText(10)), out.String())
+}
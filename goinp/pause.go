@@ -0,0 +1,141 @@
+package goinp
+
+import (
+	"time"
+)
+
+//=======================================
+// Pause
+//=======================================
+
+// pauseOptions are the options supported by Pause and WaitForKey.
+var pauseOptions = []string{"WithTimeout", "WithAbortKeys", "WithSummaryFormatter"}
+
+// Pause prints the message and waits until the user presses any key in TTY mode, or Enter in line mode.
+// It continues without a key press once the timeout set by WithTimeout expires.
+// The keys set by WithAbortKeys (and Ctrl-C in TTY mode) return ErrInterrupted.
+func Pause(messageToPrint string, opts ...Option) error {
+	c := newConfig(opts)
+	if err := c.checkOptions("Pause", pauseOptions); err != nil {
+		return err
+	}
+	_, err := waitForKey(c, messageToPrint)
+	return err
+}
+
+// WaitForKey waits for a key press like Pause, and returns the key: the character typed in, '\n' for Enter
+// (always in line mode) and 0 for the other special keys or if the timeout expired.
+func WaitForKey(messageToPrint string, opts ...Option) (rune, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("WaitForKey", pauseOptions); err != nil {
+		return 0, err
+	}
+	return waitForKey(c, messageToPrint)
+}
+
+func waitForKey(c *config, messageToPrint string) (r rune, err error) {
+	theme := c.currentTheme()
+	if c.summaryFormatter == nil {
+		c.summaryFormatter = func(question, _ string) string {
+			return theme.Success.render("✓") + " " + theme.Prompt.render(question)
+		}
+	}
+	defer func() { c.finishQuestion(messageToPrint, "", err) }()
+
+	hint := c.message(MsgPauseEnter)
+	if c.console != nil {
+		hint = c.message(MsgPauseAnyKey)
+	}
+	if c.timeout > 0 {
+		hint += " " + c.message(MsgPauseTimeout, timeoutString(c.timeout))
+	}
+	prompt := theme.Prompt.render(messageToPrint) + " " + theme.Help.render(hint)
+
+	c.asked = true
+	if c.console != nil {
+		return c.waitForKeyPress(prompt)
+	}
+	return c.waitForEnter(prompt)
+}
+
+// waitForKeyPress waits for a key press in raw mode.
+func (c *config) waitForKeyPress(prompt string) (rune, error) {
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return 0, err
+	}
+	defer restore()
+
+	if c.screen == nil {
+		c.screen = &screen{c: c}
+	}
+	c.screen.render([]string{prompt}, 0, displayWidth(prompt))
+
+	if ok, err := c.waitForInput(); err != nil || !ok {
+		return 0, err
+	}
+	k, err := readKey(c.input())
+	if err != nil {
+		return 0, err
+	}
+
+	var r rune
+	switch k.code {
+	case keyInterrupt:
+		return 0, ErrInterrupted
+	case keyEOF:
+		return 0, ErrEOF
+	case keyEnter:
+		r = '\n'
+	case keyEscape:
+		r = '\x1b'
+	case keyRune:
+		r = k.r
+	}
+	if r != 0 && c.isAbortKey(r) {
+		return 0, ErrInterrupted
+	}
+	return r, nil
+}
+
+// waitForEnter waits for a line in line mode. A line consisting of an abort key returns ErrInterrupted.
+func (c *config) waitForEnter(prompt string) (rune, error) {
+	c.printf("%s ", prompt)
+
+	if ok, err := c.waitForInput(); err != nil || !ok {
+		return 0, err
+	}
+	line, err := readLine(c.input())
+	if err != nil {
+		return 0, err
+	}
+	if runes := []rune(line); len(runes) == 1 && c.isAbortKey(runes[0]) {
+		return 0, ErrInterrupted
+	}
+	return '\n', nil
+}
+
+// waitForInput waits until there is input to read, false if the timeout set by WithTimeout expired first.
+func (c *config) waitForInput() (bool, error) {
+	if c.timeout <= 0 || c.input().Buffered() > 0 {
+		return true, nil
+	}
+	return waitForInput(c.reader, c.timeout)
+}
+
+func (c *config) isAbortKey(r rune) bool {
+	for _, key := range c.abortKeys {
+		if key == r {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutString formats the timeout for the hint, like "10s" or "1m30s".
+func timeoutString(timeout time.Duration) string {
+	if timeout < time.Second {
+		return timeout.String()
+	}
+	return timeout.Round(time.Second).String()
+}
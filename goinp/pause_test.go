@@ -0,0 +1,79 @@
+package goinp
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause(t *testing.T) {
+	t.Log("Line mode - Enter")
+	{
+		var out bytes.Buffer
+		err := Pause("Open https://app.bitrise.io, then come back", WithReader(strings.NewReader("\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, "Open https://app.bitrise.io, then come back (press Enter to continue) \n", out.String())
+	}
+
+	t.Log("Line mode - abort key")
+	{
+		err := Pause("Continue", WithReader(strings.NewReader("q\n")), WithWriter(&bytes.Buffer{}), WithAbortKeys('q'))
+		require.Equal(t, ErrInterrupted, err)
+
+		err = Pause("Continue", WithReader(strings.NewReader("quit\n")), WithWriter(&bytes.Buffer{}), WithAbortKeys('q'))
+		require.NoError(t, err)
+
+		err = Pause("Continue", WithReader(strings.NewReader("")), WithWriter(&bytes.Buffer{}))
+		require.Equal(t, ErrEOF, err)
+	}
+
+	t.Log("Timeout")
+	{
+		reader, writer, err := os.Pipe()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, reader.Close())
+			require.NoError(t, writer.Close())
+		}()
+
+		var out bytes.Buffer
+		start := time.Now()
+		err = Pause("Continue", WithReader(reader), WithWriter(&out), WithTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+		require.True(t, time.Since(start) >= 50*time.Millisecond)
+		require.Equal(t, "Continue (press Enter to continue) (continuing in 50ms) \n", out.String())
+	}
+}
+
+func TestWaitForKey(t *testing.T) {
+	t.Log("Any key")
+	{
+		var out bytes.Buffer
+		key, err := WaitForKey("Review the changes", WithReader(strings.NewReader("x")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, 'x', key)
+		require.Contains(t, out.String(), "Review the changes (press any key to continue)")
+		require.Equal(t, "✓ Review the changes\n", lastFrame(out.String()))
+
+		key, err = WaitForKey("Review the changes", WithReader(strings.NewReader("\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, '\n', key)
+
+		key, err = WaitForKey("Review the changes", WithReader(strings.NewReader("\x1b[A")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, rune(0), key)
+	}
+
+	t.Log("Abort keys")
+	{
+		_, err := WaitForKey("Review the changes", WithReader(strings.NewReader("\x03")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.Equal(t, ErrInterrupted, err)
+
+		_, err = WaitForKey("Review the changes", WithReader(strings.NewReader("\x1b")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithAbortKeys('q', '\x1b'))
+		require.Equal(t, ErrInterrupted, err)
+	}
+}
@@ -0,0 +1,351 @@
+package goinp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//=======================================
+// Select options
+//=======================================
+
+type selectOptionKind int
+
+const (
+	optionEntry selectOptionKind = iota
+	sectionEntry
+	separatorEntry
+)
+
+// SelectOption is an option of Select.
+type SelectOption struct {
+	// Value is returned if the option is selected.
+	Value string
+	// Label is shown instead of the value, if set.
+	Label string
+	// Description is shown in a secondary line under the option.
+	Description string
+	// Tag is shown right-aligned after the option, like "recommended".
+	Tag string
+	// Disabled options are listed, but they can't be selected and get no number. DisabledReason is shown after them.
+	Disabled       bool
+	DisabledReason string
+
+	kind selectOptionKind
+}
+
+// Section returns a section header, listed between the options of Select.
+func Section(title string) SelectOption {
+	return SelectOption{Label: title, kind: sectionEntry}
+}
+
+// Separator returns a line separating the options of Select.
+func Separator() SelectOption {
+	return SelectOption{kind: separatorEntry}
+}
+
+func (o SelectOption) label() string {
+	if o.Label != "" {
+		return o.Label
+	}
+	return o.Value
+}
+
+func (o SelectOption) selectable() bool {
+	return o.kind == optionEntry && !o.Disabled
+}
+
+// Select asks the user to select one of the options by typing in its number, or with the arrow keys in TTY mode,
+// and returns the value of the selected option. Only the enabled options are numbered.
+// The default value can be given either as the option's value or as the option's number (see WithDefault).
+func Select(messageToPrint string, options []SelectOption, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("Select", questionOptions, []string{"WithPageSize"}); err != nil {
+		return "", err
+	}
+	selected, err := selectFrom(c, messageToPrint, options)
+	return selected.Value, err
+}
+
+func selectFrom(c *config, messageToPrint string, options []SelectOption) (selected SelectOption, err error) {
+	defer func() { c.finishQuestion(messageToPrint, selected.label(), err) }()
+	return chooseFrom(c, messageToPrint, options)
+}
+
+// chooseFrom asks for the selected option like selectFrom, but leaves the question to be closed by finishQuestion.
+func chooseFrom(c *config, messageToPrint string, options []SelectOption) (SelectOption, error) {
+	var numbered []SelectOption
+	var values []string
+	for _, anOption := range options {
+		if anOption.selectable() {
+			numbered = append(numbered, anOption)
+			values = append(values, anOption.Value)
+		}
+	}
+
+	defaultValue, err := c.defaultOption(values)
+	if err != nil {
+		return SelectOption{}, err
+	}
+
+	check := func(answer string) error {
+		num, err := selectNumber(c, len(numbered), answer)
+		if err != nil {
+			return err
+		}
+		return c.validate(numbered[num-1].Value)
+	}
+
+	var num int
+	switch {
+	case c.console != nil:
+		num, err = c.selectInMenu(messageToPrint, options, defaultValue, check)
+	case c.pageSize > 0 && len(numbered) > c.pageSize:
+		num, err = c.selectInPages(messageToPrint, options, defaultValue, check)
+	default:
+		num, err = c.selectInList(messageToPrint, options, defaultValue, check)
+	}
+	if err != nil || num == 0 {
+		return SelectOption{}, err
+	}
+	return numbered[num-1], nil
+}
+
+// selectInList asks for the number of the selected option in line mode, listing all the options.
+// 0 is returned for an empty answer to an optional question.
+func (c *config) selectInList(messageToPrint string, options []SelectOption, defaultValue int, check func(answer string) error) (int, error) {
+	p := linePrompt{
+		header: optionLines(c, messageToPrint, options, defaultValue),
+		prompt: c.currentTheme().Help.render(c.message(MsgSelectOptionNumber)),
+		filter: IntegerChars,
+		check:  check,
+	}
+	if c.hasDefault {
+		p.hint = strconv.Itoa(defaultValue)
+	}
+
+	answer, err := c.askLine(p)
+	if err != nil {
+		return 0, err
+	}
+	return c.selectedNumber(answer, defaultValue, check)
+}
+
+// selectedNumber returns the number of the selected option for a checked answer, or the default for an empty one.
+func (c *config) selectedNumber(answer string, defaultValue int, check func(answer string) error) (int, error) {
+	if answer == "" {
+		if !c.hasDefault {
+			return 0, nil
+		}
+		answer = strconv.Itoa(defaultValue)
+		if err := check(answer); err != nil {
+			return 0, err
+		}
+	}
+	return strconv.Atoi(answer)
+}
+
+// optionLines renders the question and the numbered list of the options, selected is the number of the highlighted option (0 if none).
+func optionLines(c *config, messageToPrint string, options []SelectOption, selected int) []string {
+	theme := c.currentTheme()
+
+	lines := []string{
+		theme.Prompt.render(messageToPrint),
+		theme.Help.render(c.message(MsgSelectFromList)),
+	}
+	rows, _ := optionRows(c, options, numberOptions(options), selected)
+	return append(lines, rows...)
+}
+
+// numberOptions returns the number of each option, 0 for the entries which can't be selected.
+func numberOptions(options []SelectOption) []int {
+	numbers := make([]int, len(options))
+	num := 0
+	for idx, anOption := range options {
+		if anOption.selectable() {
+			num++
+			numbers[idx] = num
+		}
+	}
+	return numbers
+}
+
+// optionRows renders the options: "[n] : option", followed by the right-aligned tag and the description in the next row.
+// numbers are the numbers of the options (see numberOptions), positions are the indexes of the rows of the numbered options.
+func optionRows(c *config, options []SelectOption, numbers []int, selected int) (rows []string, positions []int) {
+	theme := c.currentTheme()
+
+	last := 0
+	for _, num := range numbers {
+		if num > last {
+			last = num
+		}
+	}
+	indent := strings.Repeat(" ", len(fmt.Sprintf("[%d] : ", last)))
+
+	// the plain texts are rendered first, to align the tags and the separators
+	texts := make([]string, len(options))
+	width, tagWidth := 3, 0
+	for idx, anOption := range options {
+		switch {
+		case anOption.kind != optionEntry:
+			continue
+		case anOption.Disabled:
+			texts[idx] = indent + anOption.label()
+			if anOption.DisabledReason != "" {
+				texts[idx] += " (" + anOption.DisabledReason + ")"
+			}
+		default:
+			texts[idx] = fmt.Sprintf("[%d] : %s", numbers[idx], anOption.label())
+		}
+
+		rowWidth := displayWidth(texts[idx])
+		if anOption.Tag != "" {
+			rowWidth += 2 + displayWidth(anOption.Tag)
+			if rowWidth > tagWidth {
+				tagWidth = rowWidth
+			}
+		}
+		if rowWidth > width {
+			width = rowWidth
+		}
+	}
+
+	for idx, anOption := range options {
+		switch anOption.kind {
+		case sectionEntry:
+			rows = append(rows, theme.Prompt.render(anOption.Label))
+			continue
+		case separatorEntry:
+			rows = append(rows, theme.Help.render(strings.Repeat("─", width)))
+			continue
+		}
+
+		row := texts[idx]
+		if anOption.Disabled {
+			row = theme.Help.render(row)
+		} else {
+			positions = append(positions, len(rows))
+			if numbers[idx] == selected {
+				row = theme.Selected.render(row)
+			}
+		}
+		if anOption.Tag != "" {
+			row += strings.Repeat(" ", tagWidth-displayWidth(texts[idx])-displayWidth(anOption.Tag)) + theme.DefaultHint.render(anOption.Tag)
+		}
+		rows = append(rows, row)
+
+		if anOption.Description != "" {
+			rows = append(rows, indent+theme.Help.render(anOption.Description))
+		}
+	}
+	return rows, positions
+}
+
+//=======================================
+// Pages
+//=======================================
+
+// selectInPages asks for the number of the selected option in line mode, listing the options page by page.
+// Besides a number, the answer can be "n" or "p" to move between the pages, or "/text" to filter the options.
+// 0 is returned for an empty answer to an optional question.
+func (c *config) selectInPages(messageToPrint string, options []SelectOption, defaultValue int, check func(answer string) error) (int, error) {
+	theme := c.currentTheme()
+	numbers := numberOptions(options)
+
+	filter := ""
+	page := 0
+	if defaultValue > 0 {
+		page = (defaultValue - 1) / c.pageSize
+	}
+
+	for {
+		visible, visibleNumbers := options, numbers
+		if filter != "" {
+			visible, visibleNumbers = filterOptions(options, numbers, filter)
+		}
+		pages := paginate(visibleNumbers, c.pageSize)
+		page = maxInt(minInt(page, len(pages)-1), 0)
+
+		header := optionLines(c, messageToPrint, nil, 0)
+		if filter != "" {
+			header = append(header, theme.Help.render(c.message(MsgSelectFiltered, filter)))
+		}
+		if len(visible) == 0 {
+			header = append(header, theme.Help.render(c.message(MsgSelectNoMatch)))
+		} else {
+			start, end := pages[page][0], pages[page][1]
+			rows, _ := optionRows(c, visible[start:end], visibleNumbers[start:end], defaultValue)
+			header = append(header, rows...)
+			header = append(header, theme.Help.render(c.message(MsgSelectPage, page+1, len(pages))))
+		}
+
+		p := linePrompt{
+			header: header,
+			prompt: theme.Help.render(c.message(MsgSelectOptionNumber)),
+			check: func(answer string) error {
+				if answer == "n" || answer == "p" || strings.HasPrefix(answer, "/") {
+					return nil
+				}
+				return check(answer)
+			},
+		}
+		if c.hasDefault {
+			p.hint = strconv.Itoa(defaultValue)
+		}
+
+		answer, err := c.askLine(p)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case answer == "n":
+			page++
+		case answer == "p":
+			page--
+		case strings.HasPrefix(answer, "/"):
+			filter = strings.TrimSpace(answer[1:])
+			page = 0
+		default:
+			return c.selectedNumber(answer, defaultValue, check)
+		}
+	}
+}
+
+// filterOptions returns the options (and their numbers) whose label or value contains the text, ignoring the case.
+func filterOptions(options []SelectOption, numbers []int, text string) ([]SelectOption, []int) {
+	text = strings.ToLower(text)
+
+	var filtered []SelectOption
+	var filteredNumbers []int
+	for idx, anOption := range options {
+		if anOption.kind != optionEntry {
+			continue
+		}
+		if strings.Contains(strings.ToLower(anOption.label()), text) || strings.Contains(strings.ToLower(anOption.Value), text) {
+			filtered = append(filtered, anOption)
+			filteredNumbers = append(filteredNumbers, numbers[idx])
+		}
+	}
+	return filtered, filteredNumbers
+}
+
+// paginate splits the entries into pages of size numbered options, a page is the [start, end) range of its entries.
+// The entries between two numbered options (like section headers) start the next page.
+func paginate(numbers []int, size int) [][2]int {
+	var pages [][2]int
+	start, end, count := 0, 0, 0
+	for idx, num := range numbers {
+		if num == 0 {
+			continue
+		}
+		if count == size {
+			pages = append(pages, [2]int{start, end})
+			start, count = end, 0
+		}
+		count++
+		end = idx + 1
+	}
+	return append(pages, [2]int{start, len(numbers)})
+}
@@ -0,0 +1,172 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSelectOptions() []SelectOption {
+	return []SelectOption{
+		Section("Workspaces"),
+		{Value: "App.xcworkspace", Tag: "recommended", Description: "CocoaPods workspace"},
+		Separator(),
+		Section("Projects"),
+		{Value: "App.xcodeproj", Tag: "project"},
+		{Value: "Legacy.xcodeproj", Disabled: true, DisabledReason: "no shared schemes"},
+		{Value: "Tools.xcodeproj", Label: "Tools"},
+	}
+}
+
+func TestSelect(t *testing.T) {
+	t.Log("Line mode")
+	{
+		var out bytes.Buffer
+		res, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("3\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, "Tools.xcodeproj", res)
+		require.Equal(t, `Project or workspace
+Please select from the list:
+Workspaces
+[1] : App.xcworkspace  recommended
+      CocoaPods workspace
+──────────────────────────────────────────
+Projects
+[2] : App.xcodeproj        project
+      Legacy.xcodeproj (no shared schemes)
+[3] : Tools
+(type in the option's number, then hit Enter) : 
+`, out.String())
+	}
+
+	t.Log("Disabled options can't be selected")
+	{
+		_, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("4\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+	}
+
+	t.Log("Default")
+	{
+		res, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault("App.xcodeproj"))
+		require.NoError(t, err)
+		require.Equal(t, "App.xcodeproj", res)
+	}
+}
+
+func TestSelectMenu(t *testing.T) {
+	t.Log("Arrow keys skip the disabled options")
+	{
+		var out bytes.Buffer
+		// down twice: App.xcworkspace -> App.xcodeproj -> Tools
+		res, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("\x1b[B\x1b[B\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "Tools.xcodeproj", res)
+		require.Contains(t, out.String(), "  [2] : App.xcodeproj        project\r\n        Legacy.xcodeproj (no shared schemes)\r\n> [3] : Tools\r\n")
+		require.Equal(t, "✓ Project or workspace: Tools\n", lastFrame(out.String()))
+	}
+
+	t.Log("Up wraps around")
+	{
+		res, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("\x1b[A\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, "Tools.xcodeproj", res)
+	}
+
+	t.Log("Typed in number")
+	{
+		res, err := Select("Project or workspace", testSelectOptions(), WithReader(strings.NewReader("2\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, "App.xcodeproj", res)
+	}
+
+	t.Log("Default is highlighted first")
+	{
+		res, err := SelectFromStrings("Scheme", []string{"App", "Tests"}, WithReader(strings.NewReader("\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithDefault("Tests"))
+		require.NoError(t, err)
+		require.Equal(t, "Tests", res)
+	}
+
+	t.Log("Long lists scroll")
+	{
+		var options []SelectOption
+		for _, value := range strings.Split("a b c d e f g h i j", " ") {
+			options = append(options, SelectOption{Value: value})
+		}
+		var out bytes.Buffer
+		res, err := Select("Letter", options, WithReader(strings.NewReader("\x1b[F\r")), WithWriter(&out), withConsole(fakeConsole{80, 8}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "j", res)
+		require.Contains(t, out.String(), "Letter\r\nPlease select from the list:\r\n  [8] : h\r\n  [9] : i\r\n> [10] : j\r\n")
+	}
+}
+
+func TestSelectPages(t *testing.T) {
+	var profiles []string
+	for _, name := range strings.Split("alpha beta gamma delta epsilon zeta eta", " ") {
+		profiles = append(profiles, name+" profile")
+	}
+
+	t.Log("Page by page")
+	{
+		var out bytes.Buffer
+		res, err := SelectFromStrings("Profile", profiles, WithReader(strings.NewReader("n\np\nn\n7\n")), WithWriter(&out), WithPageSize(3))
+		require.NoError(t, err)
+		require.Equal(t, "eta profile", res)
+		require.True(t, strings.HasPrefix(out.String(), `Profile
+Please select from the list:
+[1] : alpha profile
+[2] : beta profile
+[3] : gamma profile
+Page 1/3 (n: next page, p: previous page, /text: filter)
+(type in the option's number, then hit Enter) : Profile
+Please select from the list:
+[4] : delta profile
+[5] : epsilon profile
+[6] : zeta profile
+Page 2/3 (n: next page, p: previous page, /text: filter)
+`))
+	}
+
+	t.Log("Any number can be typed in")
+	{
+		res, err := SelectFromStrings("Profile", profiles, WithReader(strings.NewReader("6\n")), WithWriter(&bytes.Buffer{}), WithPageSize(3))
+		require.NoError(t, err)
+		require.Equal(t, "zeta profile", res)
+	}
+
+	t.Log("Filter keeps the numbers")
+	{
+		var out bytes.Buffer
+		res, err := SelectFromStrings("Profile", profiles, WithReader(strings.NewReader("/ETA\n6\n")), WithWriter(&out), WithPageSize(3))
+		require.NoError(t, err)
+		require.Equal(t, "zeta profile", res)
+		require.Contains(t, out.String(), `Options matching "ETA" (/ to clear the filter)
+[2] : beta profile
+[6] : zeta profile
+[7] : eta profile
+Page 1/1`)
+
+		out.Reset()
+		_, err = SelectFromStrings("Profile", profiles, WithReader(strings.NewReader("/xyz\n/\n1\n")), WithWriter(&out), WithPageSize(3))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "No matching options\n")
+	}
+
+	t.Log("The page of the default is shown first")
+	{
+		var out bytes.Buffer
+		res, err := SelectFromStrings("Profile", profiles, WithReader(strings.NewReader("\n")), WithWriter(&out), WithPageSize(3), WithDefault(5))
+		require.NoError(t, err)
+		require.Equal(t, "epsilon profile", res)
+		require.Contains(t, out.String(), "Page 2/3")
+	}
+
+	t.Log("Sections start the next page")
+	{
+		require.Equal(t, [][2]int{{0, 3}, {3, 5}}, paginate([]int{0, 1, 2, 0, 3}, 2))
+		require.Equal(t, [][2]int{{0, 2}}, paginate([]int{1, 2}, 2))
+	}
+}
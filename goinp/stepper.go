@@ -0,0 +1,274 @@
+package goinp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//=======================================
+// Int in range
+//=======================================
+
+// sliderWidth is the maximum width of the slider bar.
+const sliderWidth = 30
+
+// AskForIntInRange asks for an integer between min and max (both included).
+// In TTY mode the value is changed with a stepper: Up / Down (or Right / Left) change it by the step set by WithStep (1 by default),
+// Page Up / Page Down by a larger step (a tenth of the range), Home / End jump to min / max, and the value can be typed in too.
+// The value is shown on a slider bar, starting from the default value (min if there is no default).
+// In line mode it's asked like AskForInt, rejecting the values out of the range with ErrOutOfRange.
+func AskForIntInRange(messageToPrint string, min, max int64, opts ...Option) (int64, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("AskForIntInRange", []string{"WithDefault", "WithValidator", "WithHelp", "WithHelpURL", "WithSummaryFormatter", "WithStep"}); err != nil {
+		return 0, err
+	}
+	return askForIntInRange(c, messageToPrint, min, max)
+}
+
+func askForIntInRange(c *config, messageToPrint string, min, max int64) (value int64, err error) {
+	defer func() { c.finishQuestion(messageToPrint, strconv.FormatInt(value, 10), err) }()
+
+	if min > max {
+		return 0, fmt.Errorf("invalid range (%d-%d), min is greater than max", min, max)
+	}
+
+	theme := c.currentTheme()
+	p := linePrompt{prompt: theme.Prompt.render(messageToPrint) + " " + theme.Help.render(c.message(MsgIntRange, min, max))}
+
+	defaultValue := min
+	if c.hasDefault {
+		if defaultValue, err = c.defaultInt(); err != nil {
+			return 0, err
+		}
+		if defaultValue < min || defaultValue > max {
+			return 0, fmt.Errorf("invalid default value (%d), not between %d and %d", defaultValue, min, max)
+		}
+		p.hint = strconv.FormatInt(defaultValue, 10)
+	}
+	p.filter = IntegerChars
+	p.check = func(answer string) error {
+		value, err := c.parseInt(answer)
+		if err != nil {
+			return err
+		}
+		if value < min || value > max {
+			return &ValidationError{Input: answer, Message: c.message(MsgIntOutOfRange, min, max), Err: ErrOutOfRange}
+		}
+		return c.validate(answer)
+	}
+
+	if c.console != nil {
+		return c.askInStepper(messageToPrint, p, min, max, defaultValue)
+	}
+
+	answer, err := c.askLine(p)
+	if err != nil {
+		return 0, err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return c.parseInt(answer)
+}
+
+//=======================================
+// Stepper
+//=======================================
+
+// stepper changes an integer in raw mode, with the arrow keys or by typing it in.
+type stepper struct {
+	c      *config
+	screen *screen
+	prompt string
+	check  func(answer string) error
+
+	min      int64
+	max      int64
+	step     int64
+	pageStep int64
+
+	value    int64
+	input    []rune
+	showHelp bool
+	done     bool
+	err      error
+}
+
+// askInStepper asks for the value in TTY mode, starting from the default value.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) askInStepper(messageToPrint string, p linePrompt, min, max, defaultValue int64) (int64, error) {
+	c.asked = true
+
+	restore, err := c.console.makeRaw()
+	if err != nil {
+		return 0, err
+	}
+	defer restore()
+
+	if c.screen == nil {
+		c.screen = &screen{c: c}
+	}
+
+	s := &stepper{
+		c:      c,
+		screen: c.screen,
+		prompt: c.renderPrompt(linePrompt{prompt: p.prompt}),
+		check:  p.check,
+		min:    min,
+		max:    max,
+		step:   1,
+		value:  defaultValue,
+	}
+	if c.step > 0 {
+		s.step = c.step
+	}
+	// max - min would overflow on wide ranges
+	s.pageStep = max/10 - min/10
+	if s.pageStep < s.step {
+		s.pageStep = s.step
+	}
+	return s.run()
+}
+
+func (s *stepper) run() (int64, error) {
+	input := s.c.input()
+	for {
+		s.render()
+
+		k, err := readKey(input)
+		if err != nil {
+			s.finish()
+			return 0, err
+		}
+
+		switch k.code {
+		case keyInterrupt:
+			s.finish()
+			return 0, ErrInterrupted
+		case keyEOF:
+			if len(s.input) == 0 {
+				s.finish()
+				return 0, ErrEOF
+			}
+		case keyEnter:
+			answer := strconv.FormatInt(s.value, 10)
+			if len(s.input) > 0 {
+				answer = string(s.input)
+			}
+			if s.err = s.check(answer); s.err != nil {
+				continue
+			}
+			s.value, _ = s.c.parseInt(answer)
+			s.input = nil
+			s.finish()
+			return s.value, nil
+		case keyUp, keyRight:
+			s.change(s.step)
+		case keyDown, keyLeft:
+			s.change(-s.step)
+		case keyPageUp:
+			s.change(s.pageStep)
+		case keyPageDown:
+			s.change(-s.pageStep)
+		case keyHome:
+			s.input = nil
+			s.value = s.min
+		case keyEnd:
+			s.input = nil
+			s.value = s.max
+		case keyBackspace:
+			if len(s.input) > 0 {
+				s.input = s.input[:len(s.input)-1]
+			}
+		case keyRune:
+			if k.r == '?' && len(s.input) == 0 && s.c.hasHelp() {
+				s.showHelp = !s.showHelp
+				continue
+			}
+			if !IntegerChars(k.r) {
+				continue
+			}
+			s.input = append(s.input, k.r)
+		}
+
+		s.err = nil
+		if len(s.input) > 0 {
+			if s.err = s.check(string(s.input)); s.err == nil {
+				s.value, _ = s.c.parseInt(string(s.input))
+			}
+		}
+	}
+}
+
+// change changes the value by delta, within the range.
+// The distances to the ends of the range are compared as unsigned values, they can exceed the int64 range.
+func (s *stepper) change(delta int64) {
+	s.input = nil
+
+	switch {
+	case delta > 0 && uint64(delta) >= uint64(s.max)-uint64(s.value):
+		s.value = s.max
+	case delta < 0 && uint64(-delta) >= uint64(s.value)-uint64(s.min):
+		s.value = s.min
+	default:
+		s.value += delta
+	}
+}
+
+// slider renders the value on a bar between the min and max values, like "1 ━━━━●────── 10".
+func (s *stepper) slider() string {
+	theme := s.c.currentTheme()
+
+	minLabel, maxLabel := strconv.FormatInt(s.min, 10), strconv.FormatInt(s.max, 10)
+	termWidth, _ := s.c.console.size()
+	width := minInt(sliderWidth, termWidth-len(minLabel)-len(maxLabel)-3)
+	if width < 2 {
+		return ""
+	}
+
+	position := 0
+	if s.max > s.min {
+		ratio := float64(uint64(s.value)-uint64(s.min)) / float64(uint64(s.max)-uint64(s.min))
+		position = maxInt(minInt(int(ratio*float64(width-1)), width-1), 0)
+	}
+	return minLabel + " " + theme.Selected.render(strings.Repeat("━", position)+"●") +
+		theme.Help.render(strings.Repeat("─", width-1-position)) + " " + maxLabel
+}
+
+func (s *stepper) lines() []string {
+	theme := s.c.currentTheme()
+
+	answer := string(s.input)
+	if len(s.input) == 0 {
+		answer = strconv.FormatInt(s.value, 10)
+	}
+	lines := []string{s.prompt + answer}
+	if s.done {
+		return lines
+	}
+
+	if slider := s.slider(); slider != "" {
+		lines = append(lines, slider)
+	}
+	lines = append(lines, theme.Help.render(s.c.message(MsgStepperHint, s.pageStep)))
+	if s.err != nil {
+		lines = append(lines, theme.Error.render(s.err.Error()))
+	}
+	if s.showHelp {
+		lines = append(lines, s.c.helpLines()...)
+	}
+	return lines
+}
+
+func (s *stepper) render() {
+	lines := s.lines()
+	s.screen.render(lines, 0, displayWidth(lines[0]))
+}
+
+// finish draws the final state of the stepper, without the slider and the help.
+func (s *stepper) finish() {
+	s.showHelp = false
+	s.done = true
+	s.render()
+}
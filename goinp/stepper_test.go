@@ -0,0 +1,89 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskForIntInRange(t *testing.T) {
+	t.Log("Line mode")
+	{
+		var out bytes.Buffer
+		res, err := AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("4\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, int64(4), res)
+		require.Equal(t, "Parallel jobs (1-10) : \n", out.String())
+	}
+
+	t.Log("Out of range")
+	{
+		_, err := AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("11\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+		require.EqualError(t, err, "value out of range: should be between 1 and 10")
+
+		_, err = AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrEmptyInput))
+	}
+
+	t.Log("Default")
+	{
+		var out bytes.Buffer
+		res, err := AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("\n")), WithWriter(&out), WithDefault(2))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res)
+		require.Equal(t, "Parallel jobs (1-10) [2] : \n", out.String())
+
+		_, err = AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(20))
+		require.EqualError(t, err, "invalid default value (20), not between 1 and 10")
+	}
+}
+
+func TestStepper(t *testing.T) {
+	t.Log("Up / Down, the value stays in the range")
+	{
+		var out bytes.Buffer
+		res, err := AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("\x1b[B\x1b[A\x1b[A\x1b[A\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, int64(4), res)
+		require.Contains(t, out.String(), "Parallel jobs (1-10) : 4\r\n1 ━━━━━━━━━●──────────────────── 10\r\n(Up / Down: change the value, Page Up / Page Down: change it by 1, or type it in)")
+		require.Equal(t, "✓ Parallel jobs: 4\n", lastFrame(out.String()))
+	}
+
+	t.Log("Page Up / Page Down, Home / End, WithStep")
+	{
+		res, err := AskForIntInRange("Timeout", 0, 3600, WithReader(strings.NewReader("\x1b[5~\x1b[5~\x1b[6~\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithDefault(600))
+		require.NoError(t, err)
+		require.Equal(t, int64(960), res)
+
+		res, err = AskForIntInRange("Timeout", 0, 3600, WithReader(strings.NewReader("\x1b[F\x1b[B\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithStep(60))
+		require.NoError(t, err)
+		require.Equal(t, int64(3540), res)
+	}
+
+	t.Log("Full int64 range")
+	{
+		var out bytes.Buffer
+		res, err := AskForIntInRange("Offset", math.MinInt64, math.MaxInt64, WithReader(strings.NewReader("\x1b[A\x1b[5~\x1b[5~\x1b[F\x1b[A\x1b[H\x1b[B\x1b[6~\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), res)
+		require.Contains(t, out.String(), "-9223372036854775808 ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━● 9223372036854775807")
+
+		res, err = AskForIntInRange("Offset", 0, math.MaxInt64, WithReader(strings.NewReader(strings.Repeat("\x1b[5~", 12)+"\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MaxInt64), res)
+	}
+
+	t.Log("Typed in value")
+	{
+		var out bytes.Buffer
+		res, err := AskForIntInRange("Parallel jobs", 1, 10, WithReader(strings.NewReader("12\r\x7f\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res)
+		require.Contains(t, out.String(), "value out of range: should be between 1 and 10")
+	}
+}
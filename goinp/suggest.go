@@ -0,0 +1,67 @@
+package goinp
+
+import (
+	"strings"
+)
+
+// Completer returns the suggestions for the answer typed in so far.
+// The suggestions are only offered, the answer isn't restricted to them.
+type Completer func(input string) []string
+
+// maxSuggestions is the number of suggestions shown at once.
+const maxSuggestions = 5
+
+// prefixCompleter suggests the items starting with the input, ignoring the case.
+func prefixCompleter(items []string) Completer {
+	return func(input string) []string {
+		var suggestions []string
+		for _, item := range items {
+			if strings.HasPrefix(strings.ToLower(item), strings.ToLower(input)) {
+				suggestions = append(suggestions, item)
+			}
+		}
+		return suggestions
+	}
+}
+
+// suggestions returns the suggestions for the input, without the input itself.
+func (c *config) suggestions(input string) []string {
+	if c.completer == nil {
+		return nil
+	}
+
+	var suggestions []string
+	for _, suggestion := range c.completer(input) {
+		if suggestion != input {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions
+}
+
+// suggestionsLine renders the suggestions listed in line mode, empty if there is none.
+func (c *config) suggestionsLine(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	if len(suggestions) > maxSuggestions {
+		suggestions = append(suggestions[:maxSuggestions:maxSuggestions], "...")
+	}
+	return c.currentTheme().Help.render(c.message(MsgSuggestions, strings.Join(suggestions, ", ")))
+}
+
+// completeLine expands an answer ending with Tab in line mode.
+// If the input is the prefix of a single suggestion, the suggestion is printed and returned.
+// If there are more, they are listed and false is returned, to ask again.
+func (c *config) completeLine(prompt, input string) (string, bool) {
+	suggestions := c.completer(input)
+	switch len(suggestions) {
+	case 0:
+		return input, true
+	case 1:
+		c.printf("%s%s\n", prompt, suggestions[0])
+		return suggestions[0], true
+	}
+	c.println(c.suggestionsLine(suggestions))
+	return "", false
+}
@@ -0,0 +1,76 @@
+package goinp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionsTTY(t *testing.T) {
+	branches := []string{"main", "feature/login", "feature/logout"}
+
+	t.Log("Tab accepts the first suggestion")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Branch", WithReader(strings.NewReader("fe\t\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "feature/login", res)
+		// ghost text and dropdown
+		require.Contains(t, out.String(), "Branch : feature/login\r\n> feature/login\r\n  feature/logout")
+	}
+
+	t.Log("Down selects, Right accepts")
+	{
+		res, err := AskForString("Branch", WithReader(strings.NewReader("fe\x1b[B\x1b[C\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "feature/logout", res)
+	}
+
+	t.Log("The answer is not restricted to the suggestions")
+	{
+		res, err := AskForString("Branch", WithReader(strings.NewReader("develop\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "develop", res)
+	}
+
+	t.Log("Completer callback")
+	{
+		completer := func(input string) []string {
+			return []string{input + ".app"}
+		}
+		res, err := AskForString("Bundle ID", WithReader(strings.NewReader("io.bitrise\t\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithCompleter(completer))
+		require.NoError(t, err)
+		require.Equal(t, "io.bitrise.app", res)
+	}
+}
+
+func TestSuggestionsLineMode(t *testing.T) {
+	branches := []string{"main", "feature/login", "feature/logout"}
+
+	t.Log("Unique prefix is expanded")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Branch", WithReader(strings.NewReader("ma\t\n")), WithWriter(&out), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "main", res)
+		require.Equal(t, "Suggestions: main, feature/login, feature/logout\nBranch : Branch : main\n\n", out.String())
+	}
+
+	t.Log("Ambiguous prefix lists the matches")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Branch", WithReader(strings.NewReader("feature\t\nfeature/logo\t\n")), WithWriter(&out), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "feature/logout", res)
+		require.Contains(t, out.String(), "Branch : Suggestions: feature/login, feature/logout\n")
+	}
+
+	t.Log("Answer without Tab is returned as it is")
+	{
+		res, err := AskForString("Branch", WithReader(strings.NewReader("ma\n")), WithWriter(&bytes.Buffer{}), WithSuggestions(branches...))
+		require.NoError(t, err)
+		require.Equal(t, "ma", res)
+	}
+}
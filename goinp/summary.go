@@ -0,0 +1,50 @@
+package goinp
+
+import (
+	"strings"
+)
+
+// SummaryFormatter renders the line which replaces the answered question in TTY mode.
+// answer is the final answer as text (masked for secrets, joined for multiple values).
+type SummaryFormatter func(question, answer string) string
+
+const secretMask = "********"
+
+// finishQuestion closes the question, every asker calls it once it has the final answer (or the error).
+// In line mode it prints an empty line after the answer.
+// In TTY mode the question is collapsed into a summary line, or left as it is if the question failed.
+func (c *config) finishQuestion(question, answer string, err error) {
+	if !c.asked {
+		return
+	}
+	c.asked = false
+
+	if c.screen == nil {
+		c.println()
+		return
+	}
+	defer func() { c.screen = nil }()
+
+	if err != nil {
+		c.screen.close()
+		return
+	}
+
+	if c.secret && answer != "" {
+		answer = secretMask
+	}
+	c.screen.closeWith([]string{c.summary(question, answer)})
+}
+
+func (c *config) summary(question, answer string) string {
+	if c.summaryFormatter != nil {
+		return c.summaryFormatter(question, answer)
+	}
+
+	theme := c.currentTheme()
+	question = strings.TrimRight(question, " :")
+	if strings.HasSuffix(question, "?") {
+		return theme.Success.render("✓") + " " + theme.Prompt.render(question) + " " + theme.Selected.render(answer)
+	}
+	return theme.Success.render("✓") + " " + theme.Prompt.render(question+":") + " " + theme.Selected.render(answer)
+}
@@ -0,0 +1,118 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lastFrame returns the text drawn after the last "clear to the end of the screen" sequence, without the cursor movements.
+func lastFrame(out string) string {
+	frame := out[strings.LastIndex(out, "\x1b[J")+len("\x1b[J"):]
+	frame = stripANSI(frame)
+	return strings.Replace(frame, "\r", "", -1)
+}
+
+func TestSummary(t *testing.T) {
+	t.Log("String")
+	{
+		var out bytes.Buffer
+		_, err := AskForString("App name", WithReader(strings.NewReader("my-app\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "✓ App name: my-app\n", lastFrame(out.String()))
+	}
+
+	t.Log("Bool - question mark")
+	{
+		var out bytes.Buffer
+		_, err := AskForBool("Deploy now?", WithReader(strings.NewReader("\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithDefault(true))
+		require.NoError(t, err)
+		require.Equal(t, "✓ Deploy now? yes\n", lastFrame(out.String()))
+
+		// an empty optional answer is not a no
+		out.Reset()
+		res, err := AskForBool("Deploy now?", WithReader(strings.NewReader("\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), Optional())
+		require.NoError(t, err)
+		require.False(t, res)
+		require.Equal(t, "✓ Deploy now? \n", lastFrame(out.String()))
+	}
+
+	t.Log("Select - the options are collapsed too")
+	{
+		var out bytes.Buffer
+		_, err := SelectFromStrings("Scheme", []string{"App", "Tests"}, WithReader(strings.NewReader("2\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "✓ Scheme: Tests\n", lastFrame(out.String()))
+		// the cursor is moved from the prompt (below the 2 options and 2 header lines) to the top of the block
+		require.Contains(t, out.String(), "\x1b[4A\r\x1b[J✓ Scheme: Tests")
+	}
+
+	t.Log("Select or create - only the entered value is summarized")
+	{
+		var out bytes.Buffer
+		res, custom, err := SelectOrCreate("Scheme", []string{"App", "Tests"}, WithReader(strings.NewReader("3\rApp Store\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "App Store", res)
+		require.True(t, custom)
+		require.NotContains(t, out.String(), "✓ Scheme: Other (enter manually)")
+		require.Equal(t, "✓ Scheme: App Store\n", lastFrame(out.String()))
+		// the value is asked in place of the options
+		require.Contains(t, out.String(), "\x1b[5A\r\x1b[JScheme : ")
+	}
+
+	t.Log("Secret")
+	{
+		var out bytes.Buffer
+		res, err := AskForString("Password", WithReader(strings.NewReader("s3cr3t\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), Secret())
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", res)
+		require.NotContains(t, out.String(), "s3cr3t")
+		require.Contains(t, out.String(), "Password : ******")
+		require.Equal(t, "✓ Password: ********\n", lastFrame(out.String()))
+	}
+
+	t.Log("Int - empty optional answer, default value")
+	{
+		var out bytes.Buffer
+		res, err := AskForInt("Jobs", WithReader(strings.NewReader("\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), Optional())
+		require.NoError(t, err)
+		require.Equal(t, int64(0), res)
+		require.Equal(t, "✓ Jobs: \n", lastFrame(out.String()))
+
+		out.Reset()
+		res, err = AskForInt("Jobs", WithReader(strings.NewReader("\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme), WithDefault(2))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res)
+		require.Equal(t, "✓ Jobs: 2\n", lastFrame(out.String()))
+	}
+
+	t.Log("Formatter")
+	{
+		var out bytes.Buffer
+		_, err := AskForInt("Jobs", WithReader(strings.NewReader("4\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme),
+			WithSummaryFormatter(func(question, answer string) string {
+				return question + " = " + answer
+			}))
+		require.NoError(t, err)
+		require.Equal(t, "Jobs = 4\n", lastFrame(out.String()))
+	}
+
+	t.Log("Failed question is left as it is")
+	{
+		var out bytes.Buffer
+		_, err := AskForInt("Jobs", WithReader(strings.NewReader("12\x03")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.True(t, errors.Is(err, ErrInterrupted))
+		require.Equal(t, "Jobs : 12\n", lastFrame(out.String()))
+	}
+
+	t.Log("Line mode is not affected")
+	{
+		var out bytes.Buffer
+		_, err := AskForString("App name", WithReader(strings.NewReader("my-app\n")), WithWriter(&out), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, "App name : \n", out.String())
+	}
+}
@@ -0,0 +1,291 @@
+package goinp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//=======================================
+// Table select
+//=======================================
+
+// minColumnWidth is the width the columns are shrunk to at most, when the table is wider than the terminal.
+const minColumnWidth = 4
+
+// SelectFromTable asks the user to select a row of the table, and returns its index.
+// The columns are aligned, and in TTY mode the cells are truncated to fit the terminal and the rows
+// can be sorted by any column (Tab selects the next column, Shift-Tab reverses the order).
+// The rows are numbered in their original order. The validators get the first column of the row.
+// The default value is the number of the row (index + 1), see WithDefault.
+// Every row has to have a cell for each header.
+func SelectFromTable(messageToPrint string, headers []string, rows [][]string, opts ...Option) (int, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("SelectFromTable", questionOptions, []string{"WithPageSize"}); err != nil {
+		return -1, err
+	}
+	return selectFromTable(c, messageToPrint, headers, rows)
+}
+
+func selectFromTable(c *config, messageToPrint string, headers []string, rows [][]string) (int, error) {
+	if len(headers) == 0 {
+		return -1, errors.New("invalid table, it has no headers")
+	}
+	for idx, row := range rows {
+		if len(row) != len(headers) {
+			return -1, fmt.Errorf("invalid table, row %d has %d cells instead of %d", idx+1, len(row), len(headers))
+		}
+	}
+
+	t := &table{headers: headers, rows: rows, sortColumn: -1}
+	t.widths = columnWidths(headers, rows)
+	if c.console != nil {
+		// room for the sort indicator
+		for col, header := range headers {
+			t.widths[col] = maxInt(t.widths[col], displayWidth(header)+2)
+		}
+		width, _ := c.console.size()
+		// the menu's marker, the row number and a column for the cursor
+		t.fit(width - 2 - len(t.indent()) - 1)
+	}
+
+	// the validators get the first column of the row, instead of the option's value (the row's number)
+	validators := c.validators
+	tableConfig := *c
+	tableConfig.validators = []Validator{func(answer string) error {
+		num, err := strconv.Atoi(answer)
+		if err != nil {
+			return err
+		}
+		for _, validator := range validators {
+			if err := validator(firstCell(rows[num-1])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+
+	var selected SelectOption
+	var err error
+	if c.console != nil {
+		selected, err = tableConfig.selectTableInMenu(messageToPrint, t)
+	} else {
+		options, _ := t.options()
+		selected, err = selectFrom(&tableConfig, messageToPrint, options)
+	}
+	if err != nil || selected.Value == "" {
+		return -1, err
+	}
+	num, err := strconv.Atoi(selected.Value)
+	if err != nil {
+		return -1, err
+	}
+	return num - 1, nil
+}
+
+// selectTableInMenu selects a row in TTY mode, the keys of the menu sort the rows.
+func (c *config) selectTableInMenu(messageToPrint string, t *table) (selected SelectOption, err error) {
+	defer func() { c.finishQuestion(messageToPrint, selected.label(), err) }()
+
+	var values []string
+	for idx := range t.rows {
+		values = append(values, strconv.Itoa(idx+1))
+	}
+	defaultValue, err := c.defaultOption(values)
+	if err != nil {
+		return SelectOption{}, err
+	}
+
+	m := &menu{
+		title: append(optionLines(c, messageToPrint, nil, 0), c.currentTheme().Help.render(c.message(MsgTableSortHint))),
+		check: func(answer string) error {
+			num, err := selectNumber(c, len(t.rows), answer)
+			if err != nil {
+				return err
+			}
+			return c.validate(strconv.Itoa(num))
+		},
+	}
+	m.options, m.numbers = t.options()
+	m.keys = t.sortKeys(m)
+
+	answer, err := c.runMenu(m, c.defaultAnswer(defaultValue))
+	if err != nil {
+		return SelectOption{}, err
+	}
+	num, err := strconv.Atoi(answer)
+	if err != nil {
+		return SelectOption{}, err
+	}
+	return SelectOption{Value: strconv.Itoa(num), Label: t.format(t.rows[num-1])}, nil
+}
+
+func firstCell(row []string) string {
+	if len(row) == 0 {
+		return ""
+	}
+	return row[0]
+}
+
+// table lays out the rows of SelectFromTable.
+type table struct {
+	headers []string
+	rows    [][]string
+	widths  []int
+
+	// sortColumn is the index of the column the rows are sorted by, -1 if they are in their original order.
+	sortColumn int
+	descending bool
+}
+
+func columnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for col, header := range headers {
+		widths[col] = displayWidth(header)
+	}
+	for _, row := range rows {
+		for col, cell := range row {
+			if col < len(widths) && displayWidth(cell) > widths[col] {
+				widths[col] = displayWidth(cell)
+			}
+		}
+	}
+	return widths
+}
+
+// fit shrinks the widest columns until the table fits the width, or all of them are at the minimum width.
+func (t *table) fit(width int) {
+	for t.width() > width {
+		widest := 0
+		for col := range t.widths {
+			if t.widths[col] > t.widths[widest] {
+				widest = col
+			}
+		}
+		if t.widths[widest] <= minColumnWidth {
+			return
+		}
+		t.widths[widest]--
+	}
+}
+
+// width returns the width of a row, with two spaces between the columns.
+func (t table) width() int {
+	width := 0
+	for _, columnWidth := range t.widths {
+		width += columnWidth
+	}
+	if len(t.widths) > 1 {
+		width += 2 * (len(t.widths) - 1)
+	}
+	return width
+}
+
+// indent is the width of the row numbers, "[n] : ".
+func (t table) indent() string {
+	return strings.Repeat(" ", len(fmt.Sprintf("[%d] : ", len(t.rows))))
+}
+
+func (t table) format(cells []string) string {
+	var b strings.Builder
+	for col, columnWidth := range t.widths {
+		cell := ""
+		if col < len(cells) {
+			cell = truncate(cells[col], columnWidth)
+		}
+		if col > 0 {
+			b.WriteString("  ")
+		}
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", columnWidth-displayWidth(cell)))
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// options returns the header and the rows (in the sort order) as select options, and the numbers of the options.
+// The value of a row is its number in the original order.
+func (t table) options() ([]SelectOption, []int) {
+	headers := append([]string{}, t.headers...)
+	if t.sortColumn >= 0 {
+		if t.descending {
+			headers[t.sortColumn] += " ▼"
+		} else {
+			headers[t.sortColumn] += " ▲"
+		}
+	}
+	header := t.format(headers)
+
+	options := []SelectOption{Section(t.indent() + header)}
+	numbers := []int{0}
+	for _, idx := range t.order() {
+		options = append(options, SelectOption{Value: strconv.Itoa(idx + 1), Label: t.format(t.rows[idx])})
+		numbers = append(numbers, idx+1)
+	}
+	return options, numbers
+}
+
+// order returns the indexes of the rows in the sort order.
+func (t table) order() []int {
+	order := make([]int, len(t.rows))
+	for idx := range order {
+		order[idx] = idx
+	}
+	if t.sortColumn < 0 {
+		return order
+	}
+
+	cell := func(idx int) string {
+		if t.sortColumn < len(t.rows[idx]) {
+			return t.rows[idx][t.sortColumn]
+		}
+		return ""
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if t.descending {
+			return compareCells(cell(order[j]), cell(order[i])) < 0
+		}
+		return compareCells(cell(order[i]), cell(order[j])) < 0
+	})
+	return order
+}
+
+// compareCells compares the cells as numbers if both are numbers, as case insensitive text otherwise.
+func compareCells(a, b string) int {
+	numA, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	numB, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// sortKeys returns the key handler of the menu: Tab sorts by the next column, Shift-Tab reverses the order.
+// The highlighted row stays highlighted.
+func (t *table) sortKeys(m *menu) func(k key) bool {
+	return func(k key) bool {
+		highlighted := m.currentAnswer()
+		switch k.code {
+		case keyTab:
+			t.sortColumn = (t.sortColumn + 1) % len(t.headers)
+			t.descending = false
+		case keyShiftTab:
+			if t.sortColumn < 0 {
+				t.sortColumn = 0
+			}
+			t.descending = !t.descending
+		default:
+			return false
+		}
+		m.options, m.numbers = t.options()
+		m.follow(highlighted)
+		return true
+	}
+}
@@ -0,0 +1,108 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDevices() ([]string, [][]string) {
+	return []string{"Name", "OS", "UDID"}, [][]string{
+		{"iPhone 15", "17.2", "00008120-001A2B3C4D5E"},
+		{"テスト iPad", "16.4", "00008027-0006"},
+		{"iPhone SE", "9.3", "a1b2"},
+	}
+}
+
+func TestSelectFromTable(t *testing.T) {
+	t.Log("Line mode")
+	{
+		headers, rows := testDevices()
+		var out bytes.Buffer
+		res, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("2\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, 1, res)
+		require.Equal(t, `Device
+Please select from the list:
+      Name         OS    UDID
+[1] : iPhone 15    17.2  00008120-001A2B3C4D5E
+[2] : テスト iPad  16.4  00008027-0006
+[3] : iPhone SE    9.3   a1b2
+(type in the option's number, then hit Enter) : 
+`, out.String())
+	}
+
+	t.Log("Validators get the first column")
+	{
+		headers, rows := testDevices()
+		notSE := func(answer string) error {
+			if answer == "iPhone SE" {
+				return errors.New("not supported")
+			}
+			return nil
+		}
+		_, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("3\n")), WithWriter(&bytes.Buffer{}), WithValidator(notSE))
+		require.EqualError(t, err, "not supported")
+	}
+
+	t.Log("Default")
+	{
+		headers, rows := testDevices()
+		res, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault(3))
+		require.NoError(t, err)
+		require.Equal(t, 2, res)
+	}
+
+	t.Log("Invalid table")
+	{
+		_, err := SelectFromTable("Device", nil, nil, WithReader(strings.NewReader("\t")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.EqualError(t, err, "invalid table, it has no headers")
+
+		headers, rows := testDevices()
+		rows[1] = append(rows[1], "extra")
+		_, err = SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("1\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "invalid table, row 2 has 4 cells instead of 3")
+	}
+}
+
+func TestSelectFromTableMenu(t *testing.T) {
+	t.Log("Truncated to the terminal width")
+	{
+		headers, rows := testDevices()
+		var out bytes.Buffer
+		res, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("\r")), WithWriter(&out), withConsole(fakeConsole{40, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, 0, res)
+		require.Contains(t, out.String(), "        Name         OS    UDID\r\n> [1] : iPhone 15    17.2  00008120-00…\r\n")
+		require.Equal(t, "✓ Device: iPhone 15    17.2  00008120-00…\n", lastFrame(out.String()))
+	}
+
+	t.Log("Sorting by column keeps the numbers")
+	{
+		headers, rows := testDevices()
+		var out bytes.Buffer
+		// Tab: by name, Tab: by OS (numeric), Home and Down: the second row
+		res, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("\t\t\x1b[H\x1b[B\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, 1, res)
+		require.Contains(t, out.String(), "        Name         OS ▲  UDID\r\n  [3] : iPhone SE    9.3   a1b2\r\n> [2] : テスト iPad  16.4  00008027-0006\r\n")
+	}
+
+	t.Log("Shift-Tab reverses the order")
+	{
+		headers, rows := testDevices()
+		res, err := SelectFromTable("Device", headers, rows, WithReader(strings.NewReader("\t\x1b[Z\x1b[H\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}))
+		require.NoError(t, err)
+		require.Equal(t, 1, res)
+	}
+}
+
+func TestDisplayWidthWide(t *testing.T) {
+	require.Equal(t, 6, displayWidth("テスト"))
+	require.Equal(t, 1, displayWidth("é"))
+	require.Equal(t, "テ…", truncate("テスト", 4))
+	require.Equal(t, "abc", truncate("abc", 3))
+}
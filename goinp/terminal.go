@@ -0,0 +1,223 @@
+package goinp
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/sys/unix"
+)
+
+//=======================================
+// Console
+//=======================================
+
+// console is the terminal a question is asked on in TTY mode,
+// which is used if both the input and the output are terminals.
+type console interface {
+	// makeRaw switches the terminal into raw mode, restore switches it back.
+	makeRaw() (restore func(), err error)
+	// size returns the width and height of the terminal.
+	size() (width, height int)
+}
+
+type fileConsole struct {
+	fd int
+}
+
+func (f fileConsole) makeRaw() (func(), error) {
+	state, err := terminal.MakeRaw(f.fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		_ = terminal.Restore(f.fd, state)
+	}, nil
+}
+
+func (f fileConsole) size() (int, int) {
+	width, height, err := terminal.GetSize(f.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// detectConsole returns the console if the question can be asked in TTY mode, nil otherwise.
+// TTY mode is disabled on dumb terminals.
+func detectConsole(inputReader io.Reader, outputWriter io.Writer) console {
+	if os.Getenv("TERM") == "dumb" {
+		return nil
+	}
+
+	input, ok := inputReader.(interface{ Fd() uintptr })
+	if !ok || !terminal.IsTerminal(int(input.Fd())) {
+		return nil
+	}
+	output, ok := outputWriter.(interface{ Fd() uintptr })
+	if !ok || !terminal.IsTerminal(int(output.Fd())) {
+		return nil
+	}
+	return fileConsole{fd: int(input.Fd())}
+}
+
+// waitForInput waits until the reader has input to read, false if the timeout expired first.
+// Readers without a file descriptor can't be waited for, they are assumed to have input.
+func waitForInput(reader io.Reader, timeout time.Duration) (bool, error) {
+	file, ok := reader.(interface{ Fd() uintptr })
+	if !ok {
+		return true, nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	fds := []unix.PollFd{{Fd: int32(file.Fd()), Events: unix.POLLIN}}
+	for {
+		remaining := time.Until(deadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		// rounded up, not to return before the deadline
+		n, err := unix.Poll(fds, int((remaining+time.Millisecond-1)/time.Millisecond))
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return n > 0, nil
+	}
+}
+
+//=======================================
+// Keys
+//=======================================
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUnknown
+	keyEnter
+	keyBackspace
+	keyDelete
+	keyTab
+	keyShiftTab
+	keyEscape
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyPageUp
+	keyPageDown
+	keyInterrupt
+	keyEOF
+)
+
+// key is a key press read in raw mode, r is only set for keyRune.
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// readKey reads a key press, decoding the escape sequences of the special keys.
+func readKey(reader *bufio.Reader) (key, error) {
+	r, _, err := reader.ReadRune()
+	if err == io.EOF {
+		return key{}, ErrEOF
+	} else if err != nil {
+		return key{}, &readError{err: err}
+	}
+
+	switch r {
+	case '\r', '\n':
+		if r == '\r' && reader.Buffered() > 0 {
+			if next, err := reader.Peek(1); err == nil && next[0] == '\n' {
+				_, _ = reader.ReadByte()
+			}
+		}
+		return key{code: keyEnter}, nil
+	case '\x7f', '\b':
+		return key{code: keyBackspace}, nil
+	case '\t':
+		return key{code: keyTab}, nil
+	case keyCtrlC:
+		return key{code: keyInterrupt}, nil
+	case keyCtrlD:
+		return key{code: keyEOF}, nil
+	case '\x01':
+		return key{code: keyHome}, nil
+	case '\x05':
+		return key{code: keyEnd}, nil
+	case '\x1b':
+		// A lone escape and the start of an escape sequence can only be told apart by whether more input arrived with it.
+		if reader.Buffered() == 0 {
+			return key{code: keyEscape}, nil
+		}
+		return readEscapeSequence(reader)
+	}
+
+	if unicode.IsControl(r) {
+		return key{code: keyUnknown}, nil
+	}
+	return key{code: keyRune, r: r}, nil
+}
+
+func readEscapeSequence(reader *bufio.Reader) (key, error) {
+	introducer, err := reader.ReadByte()
+	if err != nil {
+		return key{code: keyEscape}, nil
+	}
+	if introducer != '[' && introducer != 'O' {
+		return key{code: keyUnknown}, nil
+	}
+
+	var parameter []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return key{code: keyUnknown}, nil
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return decodeEscapeSequence(string(parameter), b), nil
+		}
+		parameter = append(parameter, b)
+	}
+}
+
+func decodeEscapeSequence(parameter string, final byte) key {
+	switch final {
+	case 'A':
+		return key{code: keyUp}
+	case 'B':
+		return key{code: keyDown}
+	case 'C':
+		return key{code: keyRight}
+	case 'D':
+		return key{code: keyLeft}
+	case 'H':
+		return key{code: keyHome}
+	case 'F':
+		return key{code: keyEnd}
+	case 'Z':
+		return key{code: keyShiftTab}
+	case '~':
+		switch parameter {
+		case "1", "7":
+			return key{code: keyHome}
+		case "4", "8":
+			return key{code: keyEnd}
+		case "3":
+			return key{code: keyDelete}
+		case "5":
+			return key{code: keyPageUp}
+		case "6":
+			return key{code: keyPageDown}
+		}
+	}
+	return key{code: keyUnknown}
+}
@@ -0,0 +1,74 @@
+package goinp
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsole is used to test the questions in TTY mode.
+type fakeConsole struct {
+	width, height int
+}
+
+func (fakeConsole) makeRaw() (func(), error) {
+	return func() {}, nil
+}
+
+func (f fakeConsole) size() (int, int) {
+	return f.width, f.height
+}
+
+// withConsole forces TTY mode, the keys are read from the question's reader.
+func withConsole(con console) Option {
+	return func(c *config) {
+		c.console = con
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("a\r\n\x7f\x1b[A\x1b[B\x1b[C\x1b[D\x1b[3~\x1b[5~\x1b[6~\x1bOH\x1b[4~\t\x1b[Z\x03\x04é"))
+
+	expected := []key{
+		{code: keyRune, r: 'a'},
+		{code: keyEnter},
+		{code: keyBackspace},
+		{code: keyUp},
+		{code: keyDown},
+		{code: keyRight},
+		{code: keyLeft},
+		{code: keyDelete},
+		{code: keyPageUp},
+		{code: keyPageDown},
+		{code: keyHome},
+		{code: keyEnd},
+		{code: keyTab},
+		{code: keyShiftTab},
+		{code: keyInterrupt},
+		{code: keyEOF},
+		{code: keyRune, r: 'é'},
+	}
+	for _, expectedKey := range expected {
+		k, err := readKey(reader)
+		require.NoError(t, err)
+		require.Equal(t, expectedKey, k)
+	}
+
+	_, err := readKey(reader)
+	require.Equal(t, ErrEOF, err)
+}
+
+func TestReadKeyLoneEscape(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("\x1b"))
+	k, err := readKey(reader)
+	require.NoError(t, err)
+	require.Equal(t, key{code: keyEscape}, k)
+}
+
+func TestDisplayWidth(t *testing.T) {
+	require.Equal(t, 4, displayWidth("text"))
+	require.Equal(t, 4, displayWidth("\x1b[1;32mtext\x1b[0m"))
+	require.Equal(t, 5, displayWidth("árvíz"))
+}
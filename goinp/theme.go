@@ -0,0 +1,114 @@
+package goinp
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Style renders a text, for example by wrapping it into ANSI escape codes. A nil Style leaves the text as it is.
+type Style func(text string) string
+
+// NewStyle returns a Style wrapping the text into the given ANSI SGR codes, like "1" (bold) or "36" (cyan).
+func NewStyle(codes ...string) Style {
+	start := "\x1b[" + strings.Join(codes, ";") + "m"
+	return func(text string) string {
+		if text == "" {
+			return ""
+		}
+		return start + text + "\x1b[0m"
+	}
+}
+
+func (s Style) render(text string) string {
+	if s == nil {
+		return text
+	}
+	return s(text)
+}
+
+// Theme defines how the parts of a question are rendered.
+type Theme struct {
+	// Prompt is the question itself.
+	Prompt Style
+	// DefaultHint is the default value or the accepted choices after the question, like "[yes/no]".
+	DefaultHint Style
+	// Selected is the selected (or default) option of a list.
+	Selected Style
+	// Error is used for errors and warnings.
+	Error Style
+	// Help is used for instructions, like "(type in the option's number, then hit Enter)".
+	Help Style
+	// Placeholder is the example answer shown in the empty input (see WithPlaceholder).
+	Placeholder Style
+	// Success is the mark of an answered question in the summary line.
+	Success Style
+	// Added and Removed are the changed lines of a diff (see ConfirmDiff),
+	// AddedHighlight and RemovedHighlight the changed part of the lines.
+	Added            Style
+	Removed          Style
+	AddedHighlight   Style
+	RemovedHighlight Style
+}
+
+// PlainTheme prints every text as it is.
+var PlainTheme = Theme{}
+
+// ColorTheme uses ANSI colors and bold text.
+var ColorTheme = Theme{
+	Prompt:      NewStyle("1"),
+	DefaultHint: NewStyle("36"),
+	Selected:    NewStyle("1", "32"),
+	Error:       NewStyle("31"),
+	Help:        NewStyle("90"),
+	Placeholder: NewStyle("2"),
+	Success:     NewStyle("32"),
+
+	Added:            NewStyle("32"),
+	Removed:          NewStyle("31"),
+	AddedHighlight:   NewStyle("1", "30", "42"),
+	RemovedHighlight: NewStyle("1", "30", "41"),
+}
+
+// themeMutex guards theme, it can be set while questions are asked.
+var themeMutex sync.RWMutex
+
+// theme set by SetTheme, if nil the theme is chosen based on the output (ColorTheme if it supports colors, PlainTheme otherwise).
+var theme *Theme
+
+// SetTheme sets the theme of the questions.
+func SetTheme(newTheme Theme) {
+	themeMutex.Lock()
+	defer themeMutex.Unlock()
+	theme = &newTheme
+}
+
+// globalTheme returns the theme set by SetTheme, nil if none is set.
+func globalTheme() *Theme {
+	themeMutex.RLock()
+	defer themeMutex.RUnlock()
+	return theme
+}
+
+// DefaultTheme returns ColorTheme if the standard output supports colors, PlainTheme otherwise.
+// Colors are disabled if the output is not a terminal, if the NO_COLOR environment variable is set or if TERM is "dumb".
+func DefaultTheme() Theme {
+	if colorSupported(os.Stdout) {
+		return ColorTheme
+	}
+	return PlainTheme
+}
+
+func colorSupported(outputWriter io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	file, ok := outputWriter.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return terminal.IsTerminal(int(file.Fd()))
+}
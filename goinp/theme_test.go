@@ -0,0 +1,131 @@
+package goinp
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what fn printed to the standard output.
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	originalStdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = originalStdout }()
+
+	fn()
+
+	require.NoError(t, writer.Close())
+	out, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestNewStyle(t *testing.T) {
+	require.Equal(t, "\x1b[1;32mtext\x1b[0m", NewStyle("1", "32")("text"))
+	require.Equal(t, "", NewStyle("1")(""))
+	require.Equal(t, "text", Style(nil).render("text"))
+}
+
+func TestDefaultTheme(t *testing.T) {
+	t.Log("Output is not a terminal")
+	{
+		t.Setenv("NO_COLOR", "")
+		t.Setenv("TERM", "xterm-256color")
+
+		var out strings.Builder
+		require.False(t, colorSupported(&out))
+	}
+
+	t.Log("NO_COLOR")
+	{
+		t.Setenv("NO_COLOR", "1")
+		require.False(t, colorSupported(os.Stdout))
+	}
+
+	t.Log("Dumb terminal")
+	{
+		t.Setenv("NO_COLOR", "")
+		t.Setenv("TERM", "dumb")
+		require.False(t, colorSupported(os.Stdout))
+	}
+}
+
+func TestPlainTheme(t *testing.T) {
+	SetTheme(PlainTheme)
+	defer func() { theme = nil }()
+
+	t.Log("String")
+	{
+		out := captureStdout(t, func() {
+			_, err := AskForStringFromReaderWithDefault("Enter some text", "default", strings.NewReader("text"))
+			require.NoError(t, err)
+		})
+		require.Equal(t, "Enter some text [default] : \n", out)
+	}
+
+	t.Log("Bool")
+	{
+		out := captureStdout(t, func() {
+			_, err := AskForBoolFromReaderWithDefaultValue("Yes or no?", true, strings.NewReader("y"))
+			require.NoError(t, err)
+			_, err = AskForBoolFromReader("Yes or no?", strings.NewReader("y"))
+			require.NoError(t, err)
+		})
+		require.Equal(t, "Yes or no? [YES/no]: \nYes or no? [yes/no] : \n", out)
+	}
+
+	t.Log("Select")
+	{
+		out := captureStdout(t, func() {
+			_, err := SelectFromStringsFromReaderWithDefault("Select something", 2, []string{"first", "second"}, strings.NewReader("1"))
+			require.NoError(t, err)
+		})
+		require.Equal(t, `Select something
+Please select from the list:
+[1] : first
+[2] : second
+(type in the option's number, then hit Enter) [2] : 
+`, out)
+	}
+}
+
+func TestColorTheme(t *testing.T) {
+	SetTheme(ColorTheme)
+	defer func() { theme = nil }()
+
+	out := captureStdout(t, func() {
+		_, err := SelectFromStringsFromReaderWithDefault("Select something", 2, []string{"first", "second"}, strings.NewReader("1"))
+		require.NoError(t, err)
+	})
+	require.Equal(t, "\x1b[1mSelect something\x1b[0m\n"+
+		"\x1b[90mPlease select from the list:\x1b[0m\n"+
+		"[1] : first\n"+
+		"\x1b[1;32m[2] : second\x1b[0m\n"+
+		"\x1b[90m(type in the option's number, then hit Enter)\x1b[0m \x1b[36m[2]\x1b[0m : \n", out)
+}
+
+func TestSetThemeWhileAsking(t *testing.T) {
+	defer func() { theme = nil }()
+
+	var wg sync.WaitGroup
+	for idx := 0; idx < 10; idx++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetTheme(PlainTheme)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = AskForString("Name", WithReader(strings.NewReader("bob\n")), WithWriter(&bytes.Buffer{}))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,415 @@
+package goinp
+
+import (
+	"strconv"
+	"strings"
+)
+
+//=======================================
+// Tree select
+//=======================================
+
+// TreeOption is an option of SelectFromTree, with its sub-options.
+type TreeOption struct {
+	// Value is returned if the option is selected.
+	Value string
+	// Label is shown instead of the value, if set.
+	Label    string
+	Children []TreeOption
+}
+
+func (o TreeOption) label() string {
+	if o.Label != "" {
+		return o.Label
+	}
+	return o.Value
+}
+
+// treeNode is a TreeOption in the tree, with its number ("2.1.3") and the state of the TTY menu.
+type treeNode struct {
+	option   TreeOption
+	number   string
+	depth    int
+	parent   *treeNode
+	children []*treeNode
+	expanded bool
+}
+
+func buildTree(options []TreeOption, parent *treeNode) []*treeNode {
+	nodes := make([]*treeNode, len(options))
+	for idx, anOption := range options {
+		node := &treeNode{option: anOption, number: strconv.Itoa(idx + 1), parent: parent}
+		if parent != nil {
+			node.number = parent.number + "." + node.number
+			node.depth = parent.depth + 1
+		}
+		node.children = buildTree(anOption.Children, node)
+		nodes[idx] = node
+	}
+	return nodes
+}
+
+// path returns the nodes from the root to the node.
+func (n *treeNode) path() []*treeNode {
+	var path []*treeNode
+	for node := n; node != nil; node = node.parent {
+		path = append([]*treeNode{node}, path...)
+	}
+	return path
+}
+
+func (n *treeNode) values() []string {
+	var values []string
+	for _, node := range n.path() {
+		values = append(values, node.option.Value)
+	}
+	return values
+}
+
+func (n *treeNode) labels() []string {
+	var labels []string
+	for _, node := range n.path() {
+		labels = append(labels, node.option.label())
+	}
+	return labels
+}
+
+// SelectFromTree asks the user to select an option from a tree of options, and returns the values
+// from the root to the selected option. By default only the leaves can be selected, see SelectAnyNode.
+// In line mode the option is selected by its number (like "2.1.3") or its path (like "App/Release",
+// the levels in between can be left out if the path is still unique). In TTY mode the arrow keys
+// move between the options, expand (Right) and collapse (Left) them, and the number or path can be typed in too.
+// The validators get the values joined by "/". The default value is given as a number or path (see WithDefault).
+func SelectFromTree(messageToPrint string, options []TreeOption, opts ...Option) ([]string, error) {
+	c := newConfig(opts)
+	if err := c.checkOptions("SelectFromTree", questionOptions, []string{"SelectAnyNode"}); err != nil {
+		return nil, err
+	}
+	return selectFromTree(c, messageToPrint, options)
+}
+
+func selectFromTree(c *config, messageToPrint string, options []TreeOption) (values []string, err error) {
+	var selected *treeNode
+	defer func() {
+		answer := ""
+		if selected != nil {
+			answer = strings.Join(selected.labels(), " / ")
+		}
+		c.finishQuestion(messageToPrint, answer, err)
+	}()
+
+	roots := buildTree(options, nil)
+
+	var defaultNode *treeNode
+	if c.hasDefault {
+		defaultPath, err := c.defaultString()
+		if err != nil {
+			return nil, err
+		}
+		if defaultNode, err = c.resolveTreePath(roots, defaultPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.console != nil {
+		selected, err = c.selectInTreeMenu(messageToPrint, roots, defaultNode)
+	} else {
+		selected, err = c.selectInTreeList(messageToPrint, roots, defaultNode)
+	}
+	if err != nil || selected == nil {
+		return nil, err
+	}
+	return selected.values(), nil
+}
+
+// selectInTreeList asks for the number or path of the selected option in line mode, listing the whole tree.
+// nil is returned for an empty answer to an optional question.
+func (c *config) selectInTreeList(messageToPrint string, roots []*treeNode, defaultNode *treeNode) (*treeNode, error) {
+	theme := c.currentTheme()
+
+	header := optionLines(c, messageToPrint, nil, 0)
+	var walk func(nodes []*treeNode)
+	walk = func(nodes []*treeNode) {
+		for _, node := range nodes {
+			row := treeRow(node)
+			if node == defaultNode {
+				row = theme.Selected.render(row)
+			}
+			header = append(header, strings.Repeat("  ", node.depth)+row)
+			walk(node.children)
+		}
+	}
+	walk(roots)
+
+	p := linePrompt{
+		header: header,
+		prompt: theme.Help.render(c.message(MsgTreeSelectPath)),
+		check: func(answer string) error {
+			_, err := c.resolveTreePath(roots, answer)
+			return err
+		},
+	}
+	if defaultNode != nil {
+		p.hint = defaultNode.number
+	}
+
+	answer, err := c.askLine(p)
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		return defaultNode, nil
+	}
+	return c.resolveTreePath(roots, answer)
+}
+
+func treeRow(node *treeNode) string {
+	return "[" + node.number + "] : " + node.option.label()
+}
+
+// resolveTreePath returns the node selected by its number ("2.1.3") or its path ("App/Release").
+// The node has to be a leaf (unless SelectAnyNode is set) and pass the validators.
+func (c *config) resolveTreePath(roots []*treeNode, answer string) (*treeNode, error) {
+	var node *treeNode
+	var err error
+	if isTreeNumber(answer) {
+		node, err = c.treeNodeByNumber(roots, answer)
+	} else {
+		node, err = c.treeNodeByPath(roots, answer)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(node.children) > 0 && !c.anyNode {
+		return nil, &ValidationError{Input: answer, Message: c.message(MsgTreeNotLeaf, strings.Join(node.labels(), "/")), Err: ErrInvalidOption}
+	}
+	if err := c.validate(strings.Join(node.values(), "/")); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func isTreeNumber(answer string) bool {
+	for _, part := range strings.Split(answer, ".") {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *config) treeNodeByNumber(roots []*treeNode, number string) (*treeNode, error) {
+	var node *treeNode
+	level := roots
+	for _, part := range strings.Split(number, ".") {
+		num, err := selectNumber(c, len(level), part)
+		if err != nil {
+			return nil, err
+		}
+		node = level[num-1]
+		level = node.children
+	}
+	return node, nil
+}
+
+// treeNodeByPath returns the node whose path starts with the first segment, ends with the last one
+// and contains the other segments in order. The segments match the values or the labels, ignoring the case.
+func (c *config) treeNodeByPath(roots []*treeNode, path string) (*treeNode, error) {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	var matches []*treeNode
+	var walk func(nodes []*treeNode)
+	walk = func(nodes []*treeNode) {
+		for _, node := range nodes {
+			if len(segments) > 0 && treePathMatches(node.path(), segments) {
+				matches = append(matches, node)
+			}
+			walk(node.children)
+		}
+	}
+	walk(roots)
+
+	switch len(matches) {
+	case 0:
+		return nil, &ValidationError{Input: path, Message: c.message(MsgTreeNoMatch, path), Err: ErrInvalidOption}
+	case 1:
+		return matches[0], nil
+	}
+	return nil, &ValidationError{Input: path, Message: c.message(MsgTreeAmbiguous, path), Err: ErrInvalidOption}
+}
+
+func treePathMatches(path []*treeNode, segments []string) bool {
+	matches := func(node *treeNode, segment string) bool {
+		return strings.EqualFold(node.option.Value, segment) || strings.EqualFold(node.option.label(), segment)
+	}
+
+	if !matches(path[0], segments[0]) || !matches(path[len(path)-1], segments[len(segments)-1]) {
+		return false
+	}
+	if len(segments) == 1 {
+		return len(path) == 1
+	}
+
+	// the segments in between have to appear in order between the root and the node
+	next := 1
+	for _, node := range path[1 : len(path)-1] {
+		if next < len(segments)-1 && matches(node, segments[next]) {
+			next++
+		}
+	}
+	return next == len(segments)-1 && len(path) > 1
+}
+
+//=======================================
+// Tree menu
+//=======================================
+
+// treeMenu lists the visible nodes of a tree in the menu: the roots and the children of the expanded nodes.
+// Right expands and Left collapses the highlighted node, the number or path of a node can be typed in too.
+type treeMenu struct {
+	m     *menu
+	roots []*treeNode
+}
+
+// selectInTreeMenu asks for the selected option in TTY mode, the default option is selected (and expanded to) first.
+// The question is left on the screen, to be closed by finishQuestion.
+func (c *config) selectInTreeMenu(messageToPrint string, roots []*treeNode, defaultNode *treeNode) (*treeNode, error) {
+	t := &treeMenu{roots: roots}
+	t.m = &menu{
+		title:  optionLines(c, messageToPrint, nil, 0),
+		prompt: c.message(MsgTreeSelectPath),
+		check: func(answer string) error {
+			_, err := c.resolveTreePath(roots, answer)
+			return err
+		},
+		keys:   t.keys,
+		rows:   t.rows,
+		answer: t.answer,
+		find:   t.find,
+		accept: func(r rune) bool { return true },
+	}
+
+	defaultAnswer := ""
+	if defaultNode != nil {
+		defaultAnswer = defaultNode.number
+	}
+	answer, err := c.runMenu(t.m, defaultAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return c.resolveTreePath(roots, answer)
+}
+
+// keys expands and collapses the nodes. Enter expands or collapses the highlighted node too, if it can't be selected.
+func (t *treeMenu) keys(k key) bool {
+	m := t.m
+	node := t.node(m.current)
+	if node == nil {
+		return false
+	}
+
+	switch {
+	case k.code == keyEnter && len(m.input) == 0 && len(node.children) > 0 && !m.c.anyNode:
+		node.expanded = !node.expanded
+	case k.code == keyRight:
+		m.input = nil
+		if len(node.children) > 0 {
+			if node.expanded {
+				m.current++
+			} else {
+				node.expanded = true
+			}
+		}
+	case k.code == keyLeft:
+		m.input = nil
+		if node.expanded {
+			node.expanded = false
+		} else if node.parent != nil {
+			m.current = t.index(node.parent)
+		}
+	default:
+		return false
+	}
+	m.err = nil
+	return true
+}
+
+func (t *treeMenu) rows(current int) ([]string, []int) {
+	theme := t.m.c.currentTheme()
+
+	var rows []string
+	var selectable []int
+	for idx, node := range t.visibleNodes() {
+		marker := "  "
+		if len(node.children) > 0 {
+			marker = "▸ "
+			if node.expanded {
+				marker = "▾ "
+			}
+		}
+		row := strings.Repeat("  ", node.depth) + marker + treeRow(node)
+		if idx == current {
+			row = theme.Selected.render(row)
+		}
+		rows = append(rows, row)
+		selectable = append(selectable, idx)
+	}
+	return rows, selectable
+}
+
+func (t *treeMenu) answer(current int) string {
+	if node := t.node(current); node != nil {
+		return node.number
+	}
+	return ""
+}
+
+// find returns the index of the node selected by the answer, expanding its ancestors.
+func (t *treeMenu) find(answer string) int {
+	node, err := t.m.c.resolveTreePath(t.roots, answer)
+	if err != nil {
+		return -1
+	}
+	for parent := node.parent; parent != nil; parent = parent.parent {
+		parent.expanded = true
+	}
+	return t.index(node)
+}
+
+func (t *treeMenu) node(idx int) *treeNode {
+	if nodes := t.visibleNodes(); idx < len(nodes) {
+		return nodes[idx]
+	}
+	return nil
+}
+
+func (t *treeMenu) index(node *treeNode) int {
+	for idx, visible := range t.visibleNodes() {
+		if visible == node {
+			return idx
+		}
+	}
+	return -1
+}
+
+// visibleNodes returns the nodes shown: the roots and the children of the expanded nodes.
+func (t *treeMenu) visibleNodes() []*treeNode {
+	var nodes []*treeNode
+	var walk func(level []*treeNode)
+	walk = func(level []*treeNode) {
+		for _, node := range level {
+			nodes = append(nodes, node)
+			if node.expanded {
+				walk(node.children)
+			}
+		}
+	}
+	walk(t.roots)
+	return nodes
+}
@@ -0,0 +1,118 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testTree() []TreeOption {
+	configurations := []TreeOption{{Value: "Debug"}, {Value: "Release"}}
+	return []TreeOption{
+		{Value: "App.xcodeproj", Label: "App", Children: []TreeOption{
+			{Value: "App", Children: configurations},
+			{Value: "App Tests", Children: []TreeOption{{Value: "Debug"}}},
+		}},
+		{Value: "Tools.xcodeproj", Label: "Tools", Children: []TreeOption{
+			{Value: "Lint", Children: configurations},
+		}},
+	}
+}
+
+func TestSelectFromTree(t *testing.T) {
+	t.Log("Line mode - number")
+	{
+		var out bytes.Buffer
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("1.1.2\n")), WithWriter(&out))
+		require.NoError(t, err)
+		require.Equal(t, []string{"App.xcodeproj", "App", "Release"}, res)
+		require.Equal(t, `Configuration
+Please select from the list:
+[1] : App
+  [1.1] : App
+    [1.1.1] : Debug
+    [1.1.2] : Release
+  [1.2] : App Tests
+    [1.2.1] : Debug
+[2] : Tools
+  [2.1] : Lint
+    [2.1.1] : Debug
+    [2.1.2] : Release
+(type in the option's number or path, then hit Enter) : 
+`, out.String())
+	}
+
+	t.Log("Line mode - path")
+	{
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("tools/release\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Tools.xcodeproj", "Lint", "Release"}, res)
+
+		res, err = SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("App/App Tests/Debug\n")), WithWriter(&bytes.Buffer{}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"App.xcodeproj", "App Tests", "Debug"}, res)
+
+		_, err = SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("App/Debug\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid option: App/Debug matches more options, type in its full path")
+
+		_, err = SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("App/Beta\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "invalid option: no option matches App/Beta")
+	}
+
+	t.Log("Only leaves, unless SelectAnyNode")
+	{
+		_, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("1.1\n")), WithWriter(&bytes.Buffer{}))
+		require.EqualError(t, err, "invalid option: App/App has sub-options, select one of them")
+
+		res, err := SelectFromTree("Scheme", testTree(), WithReader(strings.NewReader("1.1\n")), WithWriter(&bytes.Buffer{}), SelectAnyNode())
+		require.NoError(t, err)
+		require.Equal(t, []string{"App.xcodeproj", "App"}, res)
+	}
+
+	t.Log("Invalid number")
+	{
+		_, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("1.3\n")), WithWriter(&bytes.Buffer{}))
+		require.True(t, errors.Is(err, ErrOutOfRange))
+	}
+
+	t.Log("Default")
+	{
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("\n")), WithWriter(&bytes.Buffer{}), WithDefault("2.1.1"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Tools.xcodeproj", "Lint", "Debug"}, res)
+	}
+}
+
+func TestSelectFromTreeMenu(t *testing.T) {
+	t.Log("Expand with Right, Enter selects a leaf")
+	{
+		var out bytes.Buffer
+		// expand App, into App, expand App (scheme), into Debug, down to Release
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("\x1b[C\x1b[C\x1b[C\x1b[C\x1b[B\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, []string{"App.xcodeproj", "App", "Release"}, res)
+		require.Contains(t, out.String(), "  ▾ [1] : App\r\n    ▾ [1.1] : App\r\n        [1.1.1] : Debug\r\n>       [1.1.2] : Release\r\n    ▸ [1.2] : App Tests\r\n  ▸ [2] : Tools\r\n")
+		require.Equal(t, "✓ Configuration: App / App / Release\n", lastFrame(out.String()))
+	}
+
+	t.Log("Left collapses and moves to the parent")
+	{
+		var out bytes.Buffer
+		// expand Tools, into Lint, back to Tools, collapse Tools, Enter expands App (not a leaf), then type the path
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("\x1b[B\x1b[C\x1b[C\x1b[D\x1b[D\x1b[A\r1.2.1\r")), WithWriter(&out), withConsole(fakeConsole{80, 24}), WithTheme(PlainTheme))
+		require.NoError(t, err)
+		require.Equal(t, []string{"App.xcodeproj", "App Tests", "Debug"}, res)
+		require.Contains(t, out.String(), "> ▾ [1] : App\r\n    ▸ [1.1] : App\r\n    ▸ [1.2] : App Tests\r\n  ▸ [2] : Tools\r\n")
+	}
+
+	t.Log("The default is expanded")
+	{
+		res, err := SelectFromTree("Configuration", testTree(), WithReader(strings.NewReader("\x1b[A\r")), WithWriter(&bytes.Buffer{}), withConsole(fakeConsole{80, 24}), WithDefault("Tools/Release"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Tools.xcodeproj", "Lint", "Debug"}, res)
+	}
+}
@@ -0,0 +1,150 @@
+package goinp
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BoolVocabulary defines the words accepted as an answer for a yes/no question and how the choices are rendered.
+type BoolVocabulary struct {
+	// Yes and No list the accepted words, matched case insensitively.
+	// The first word of each is the one shown to the user.
+	Yes []string
+	No  []string
+	// Strict disables accepting the standard true/false values handled by strconv.ParseBool (1, t, true, 0, f, false, ...).
+	Strict bool
+	// Hint renders the choices printed after the question, without the surrounding brackets.
+	// defaultValue is only meaningful if hasDefault is true.
+	// If nil, the choices are rendered as "yes/no", with the default in upper case ("YES/no").
+	Hint func(yes, no string, hasDefault, defaultValue bool) string
+}
+
+// DefaultBoolVocabulary accepts yes/y and no/n, in addition to the standard strconv.ParseBool values.
+var DefaultBoolVocabulary = NewBoolVocabulary([]string{"yes", "y"}, []string{"no", "n"})
+
+// boolVocabularyMutex guards boolVocabulary, it can be set while questions are asked.
+var boolVocabularyMutex sync.RWMutex
+
+// boolVocabulary set by SetBoolVocabulary, if nil the vocabulary of the current locale is used.
+var boolVocabulary *BoolVocabulary
+
+// NewBoolVocabulary returns a vocabulary accepting the given words, for example:
+// NewBoolVocabulary([]string{"ja", "j"}, []string{"nein", "n"}).
+func NewBoolVocabulary(yes, no []string) BoolVocabulary {
+	return BoolVocabulary{Yes: yes, No: no}
+}
+
+// SetBoolVocabulary sets the vocabulary used by ParseBool and the bool askers.
+// By default the words of the current locale are used (MsgBoolYesWords and MsgBoolNoWords, comma separated).
+func SetBoolVocabulary(vocabulary BoolVocabulary) {
+	boolVocabularyMutex.Lock()
+	defer boolVocabularyMutex.Unlock()
+	boolVocabulary = &vocabulary
+}
+
+// globalBoolVocabulary returns the vocabulary set by SetBoolVocabulary, nil if none is set.
+func globalBoolVocabulary() *BoolVocabulary {
+	boolVocabularyMutex.RLock()
+	defer boolVocabularyMutex.RUnlock()
+	return boolVocabulary
+}
+
+func currentBoolVocabulary() BoolVocabulary {
+	if setVocabulary := globalBoolVocabulary(); setVocabulary != nil {
+		return *setVocabulary
+	}
+	return localeBoolVocabulary(currentLocale())
+}
+
+// localeBoolVocabulary returns the words of the locale. The English words are accepted too (unless the locale uses them
+// for the other answer), so that the answers piped in by scripts don't depend on the locale.
+func localeBoolVocabulary(vocabularyLocale string) BoolVocabulary {
+	yes := strings.Split(messageInLocale(vocabularyLocale, MsgBoolYesWords), ",")
+	no := strings.Split(messageInLocale(vocabularyLocale, MsgBoolNoWords), ",")
+	return NewBoolVocabulary(
+		appendWords(yes, strings.Split(EnglishCatalog[MsgBoolYesWords], ","), no),
+		appendWords(no, strings.Split(EnglishCatalog[MsgBoolNoWords], ","), yes),
+	)
+}
+
+// appendWords appends the extra words missing from the words, except the ones in the other words.
+func appendWords(words, extra, other []string) []string {
+	result := append([]string{}, words...)
+	for _, word := range extra {
+		if !containsWord(result, word) && !containsWord(other, word) {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+func containsWord(words []string, word string) bool {
+	for _, aWord := range words {
+		if strings.EqualFold(aWord, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse interprets the given answer using the vocabulary.
+func (v BoolVocabulary) Parse(userInputStr string) (bool, error) {
+	userInputStr = strings.TrimSpace(userInputStr)
+	if userInputStr == "" {
+		return false, &ValidationError{Err: ErrEmptyInput}
+	}
+
+	for _, word := range v.Yes {
+		if strings.EqualFold(userInputStr, word) {
+			return true, nil
+		}
+	}
+	for _, word := range v.No {
+		if strings.EqualFold(userInputStr, word) {
+			return false, nil
+		}
+	}
+
+	invalidMessage := message(MsgBoolInvalid, userInputStr, strings.Join(append(append([]string{}, v.Yes...), v.No...), ", "))
+	if v.Strict {
+		return false, &ValidationError{Input: userInputStr, Message: invalidMessage, Err: ErrInvalidOption}
+	}
+
+	value, err := strconv.ParseBool(strings.ToLower(userInputStr))
+	if err != nil {
+		return false, &ValidationError{Input: userInputStr, Message: invalidMessage, Err: err}
+	}
+	return value, nil
+}
+
+func (v BoolVocabulary) hint(hasDefault, defaultValue bool) string {
+	yes, no := firstWord(v.Yes, "yes"), firstWord(v.No, "no")
+	if v.Hint != nil {
+		return v.Hint(yes, no, hasDefault, defaultValue)
+	}
+
+	if hasDefault {
+		if defaultValue {
+			yes = strings.ToUpper(yes)
+		} else {
+			no = strings.ToUpper(no)
+		}
+	}
+	return yes + "/" + no
+}
+
+// word returns the word shown for the value.
+func (v BoolVocabulary) word(value bool) string {
+	if value {
+		return firstWord(v.Yes, "yes")
+	}
+	return firstWord(v.No, "no")
+}
+
+func firstWord(words []string, fallback string) string {
+	if len(words) == 0 {
+		return fallback
+	}
+	return words[0]
+}
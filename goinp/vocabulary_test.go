@@ -0,0 +1,105 @@
+package goinp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoolVocabularyParse(t *testing.T) {
+	german := NewBoolVocabulary([]string{"ja", "j"}, []string{"nein", "n"})
+
+	t.Log("Custom words")
+	{
+		value, err := german.Parse("Ja")
+		require.NoError(t, err)
+		require.True(t, value)
+
+		value, err = german.Parse(" nein ")
+		require.NoError(t, err)
+		require.False(t, value)
+	}
+
+	t.Log("Standard values are still accepted")
+	{
+		value, err := german.Parse("true")
+		require.NoError(t, err)
+		require.True(t, value)
+	}
+
+	t.Log("Strict")
+	{
+		strict := NewBoolVocabulary([]string{"on"}, []string{"off"})
+		strict.Strict = true
+
+		value, err := strict.Parse("off")
+		require.NoError(t, err)
+		require.False(t, value)
+
+		_, err = strict.Parse("1")
+		require.True(t, errors.Is(err, ErrInvalidOption))
+		require.EqualError(t, err, "invalid option: 1, accepted values: on, off")
+	}
+
+	t.Log("Empty")
+	{
+		_, err := german.Parse("")
+		require.True(t, errors.Is(err, ErrEmptyInput))
+	}
+}
+
+func TestBoolVocabularyHint(t *testing.T) {
+	require.Equal(t, "yes/no", DefaultBoolVocabulary.hint(false, false))
+	require.Equal(t, "YES/no", DefaultBoolVocabulary.hint(true, true))
+	require.Equal(t, "yes/NO", DefaultBoolVocabulary.hint(true, false))
+
+	french := NewBoolVocabulary([]string{"oui", "o"}, []string{"non", "n"})
+	require.Equal(t, "oui/NON", french.hint(true, false))
+
+	french.Hint = func(yes, no string, hasDefault, defaultValue bool) string {
+		if hasDefault && defaultValue {
+			return yes + " (défaut) / " + no
+		}
+		return yes + " / " + no
+	}
+	require.Equal(t, "oui (défaut) / non", french.hint(true, true))
+}
+
+func TestSetBoolVocabulary(t *testing.T) {
+	SetBoolVocabulary(NewBoolVocabulary([]string{"enable"}, []string{"disable"}))
+	defer func() { boolVocabulary = nil }()
+
+	value, err := ParseBool("Enable")
+	require.NoError(t, err)
+	require.True(t, value)
+
+	value, err = AskForBoolFromReaderWithDefaultValue("Feature?", true, strings.NewReader("disable"))
+	require.NoError(t, err)
+	require.False(t, value)
+
+	value, err = AskForBoolFromReader("Feature?", strings.NewReader("enable"))
+	require.NoError(t, err)
+	require.True(t, value)
+}
+
+func TestSetBoolVocabularyWhileAsking(t *testing.T) {
+	defer func() { boolVocabulary = nil }()
+
+	var wg sync.WaitGroup
+	for idx := 0; idx < 10; idx++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetBoolVocabulary(DefaultBoolVocabulary)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = AskForBool("Deploy?", WithReader(strings.NewReader("y\n")), WithWriter(&bytes.Buffer{}))
+		}()
+	}
+	wg.Wait()
+}